@@ -0,0 +1,183 @@
+package webserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// adminListenerName is the name of the listener of the admin server
+const adminListenerName = "admin"
+
+// These are the paths of the built-in admin endpoints, besides the health endpoints and the customized metrics path
+const (
+	adminRunningPath   = "/running"
+	adminRoutesPath    = "/routes"
+	adminLogFilterPath = "/logfilter"
+	adminPprofPath     = "/debug"
+)
+
+// AdminSetting holds the settings of the optional admin server, which hosts the operational endpoints on its own address and router instead of the listeners serving the routes
+type AdminSetting struct {
+	// Address is the TCP address of the admin server, e.g. "127.0.0.1:8081"; ignored if Listener is set
+	Address string
+	// Listener is the override of net.Listener to be used instead of the address, e.g. a UDS listener
+	Listener net.Listener
+	// ServerCert is the server certificate of the admin server; also determines the hosting security option of the admin server (HTTP v.s. HTTPS)
+	ServerCert *tls.Certificate
+	// CaCertPool is the CA cert pool for incoming client certificate validation on the admin server; if nil, no validation is conducted for incoming client certificates
+	CaCertPool *x509.CertPool
+	// CertificateProvider provides the server certificate and CA cert pool of the admin server on each TLS handshake; if set, ServerCert and CaCertPool are ignored
+	CertificateProvider CertificateProvider
+	// ClientAuthMode is the policy of requesting and verifying client certificates on the admin server; ClientAuthRequire if empty
+	ClientAuthMode ClientAuthMode
+	// EnablePprof registers the net/http/pprof endpoints under /debug/pprof of the admin server
+	EnablePprof bool
+	// Statics are the additional handlers registered on the admin router by their path prefixes
+	Statics []Static
+}
+
+// adminRoute is the JSON representation of a registered route listed by the admin server
+type adminRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+func writeAdminResponse(
+	responseWriter http.ResponseWriter,
+	body any,
+) {
+	var responseBody, _ = json.Marshal(
+		body,
+	)
+	responseWriter.Header().Set("Content-Type", ContentTypeJSON)
+	responseWriter.WriteHeader(http.StatusOK)
+	responseWriter.Write(responseBody)
+}
+
+// handleRunning reports whether the application is currently running, i.e. started and not yet received the shutdown signal
+func (app *application) handleRunning(
+	responseWriter http.ResponseWriter,
+	httpRequest *http.Request,
+) {
+	writeAdminResponse(
+		responseWriter,
+		map[string]bool{
+			"running": app.IsRunning(),
+		},
+	)
+}
+
+// listRoutes returns all routes registered on the given router, in the order of walking the router
+func listRoutes(router chi.Router) []adminRoute {
+	var routes = []adminRoute{}
+	chi.Walk(
+		router,
+		func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+			routes = append(
+				routes,
+				adminRoute{
+					Method: method,
+					Path:   route,
+				},
+			)
+			return nil
+		},
+	)
+	return routes
+}
+
+// handleRoutes returns an HTTP handler listing all routes registered on the given router
+func handleRoutes(router chi.Router) http.HandlerFunc {
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		writeAdminResponse(
+			responseWriter,
+			listRoutes(
+				router,
+			),
+		)
+	}
+}
+
+// instantiateAdminRouter instantiates the admin router with the built-in operational endpoints, listing the routes of the given router
+func instantiateAdminRouter(
+	app *application,
+	session *session,
+	setting *AdminSetting,
+	router chi.Router,
+) (chi.Router, error) {
+	var adminRouter = chi.NewRouter()
+	registerHealthChecks(
+		app,
+		adminRouter,
+	)
+	registerMetrics(
+		session,
+		adminRouter,
+	)
+	adminRouter.Get(
+		adminRunningPath,
+		app.handleRunning,
+	)
+	adminRouter.Get(
+		adminRoutesPath,
+		handleRoutes(router),
+	)
+	adminRouter.Handle(
+		adminLogFilterPath,
+		LogFilterHandler(),
+	)
+	if setting.EnablePprof {
+		adminRouter.Mount(
+			adminPprofPath,
+			middleware.Profiler(),
+		)
+	}
+	for _, static := range setting.Statics {
+		adminRouter.Handle(
+			static.PathPrefix,
+			static.Handler,
+		)
+	}
+	var walkError = walkRegisteredRoutes(
+		session,
+		adminRouter,
+	)
+	return adminRouter, walkError
+}
+
+// createAdminServer creates the admin server, which shares the lifecycle of the listeners serving the routes
+func createAdminServer(
+	app *application,
+	session *session,
+	setting *AdminSetting,
+	router chi.Router,
+) (*hostedServer, error) {
+	var adminRouter, routerError = instantiateAdminRouter(
+		app,
+		session,
+		setting,
+		router,
+	)
+	if routerError != nil {
+		return nil, routerError
+	}
+	return createServer(
+		session,
+		ListenerSetting{
+			Name:                adminListenerName,
+			Address:             setting.Address,
+			Listener:            setting.Listener,
+			ServerCert:          setting.ServerCert,
+			CaCertPool:          setting.CaCertPool,
+			CertificateProvider: setting.CertificateProvider,
+			ClientAuthMode:      setting.ClientAuthMode,
+		},
+		adminRouter,
+	), nil
+}
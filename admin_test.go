@@ -0,0 +1,224 @@
+package webserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestWriteAdminResponse(t *testing.T) {
+	// arrange
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	writeAdminResponse(
+		recorder,
+		map[string]string{"foo": "bar"},
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, ContentTypeJSON, recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"foo":"bar"}`, recorder.Body.String())
+}
+
+func TestHandleRunning(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	dummyApplication.started.Store(true)
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	dummyApplication.handleRunning(
+		recorder,
+		httptest.NewRequest(http.MethodGet, adminRunningPath, nil),
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"running":true}`, recorder.Body.String())
+}
+
+func TestListRoutes(t *testing.T) {
+	// arrange
+	var dummyRouter = chi.NewRouter()
+	dummyRouter.Get("/items", http.NotFound)
+	dummyRouter.Post("/items/{id}", http.NotFound)
+
+	// SUT + act
+	var result = listRoutes(
+		dummyRouter,
+	)
+
+	// assert
+	assert.ElementsMatch(t, []adminRoute{
+		{Method: http.MethodGet, Path: "/items"},
+		{Method: http.MethodPost, Path: "/items/{id}"},
+	}, result)
+}
+
+func TestHandleRoutes(t *testing.T) {
+	// arrange
+	var dummyRouter = chi.NewRouter()
+	dummyRouter.Get("/items", http.NotFound)
+	var recorder = httptest.NewRecorder()
+
+	// SUT
+	var sut = handleRoutes(dummyRouter)
+
+	// act
+	sut(
+		recorder,
+		httptest.NewRequest(http.MethodGet, adminRoutesPath, nil),
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `[{"method":"GET","path":"/items"}]`, recorder.Body.String())
+}
+
+func TestInstantiateAdminRouter_HappyPath(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		customization: &dummyHealthCustomization{},
+	}
+	dummyApplication.started.Store(true)
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: &dummyMetricsCustomization{metricsPath: "/metrics"},
+	}
+	var dummySetting = &AdminSetting{
+		EnablePprof: true,
+		Statics: []Static{
+			{PathPrefix: "/custom", Handler: http.HandlerFunc(func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+				responseWriter.WriteHeader(http.StatusTeapot)
+			})},
+		},
+	}
+	var dummyRouter = chi.NewRouter()
+	dummyRouter.Get("/items", http.NotFound)
+	var expectedCodes = map[string]int{
+		healthPath:                 http.StatusOK,
+		readinessPath:              http.StatusOK,
+		"/metrics":                 http.StatusOK,
+		adminRunningPath:           http.StatusOK,
+		adminRoutesPath:            http.StatusOK,
+		adminLogFilterPath:         http.StatusOK,
+		adminPprofPath + "/pprof/": http.StatusOK,
+		"/custom":                  http.StatusTeapot,
+		"/items":                   http.StatusNotFound,
+	}
+
+	// SUT + act
+	var result, err = instantiateAdminRouter(
+		dummyApplication,
+		dummySession,
+		dummySetting,
+		dummyRouter,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	for path, expectedCode := range expectedCodes {
+		var recorder = httptest.NewRecorder()
+		result.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, expectedCode, recorder.Code, path)
+	}
+}
+
+func TestInstantiateAdminRouter_WalkError(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		customization: &dummyHealthCustomization{},
+	}
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: &dummyMetricsCustomization{},
+	}
+	var dummySetting = &AdminSetting{}
+	var dummyRouter = chi.NewRouter()
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(registerMetrics).Expects(dummySession, gomocker.Anything()).Returns().Once()
+	m.Mock(walkRegisteredRoutes).Expects(dummySession, gomocker.Anything()).Returns(dummyError).Once()
+
+	// SUT + act
+	var result, err = instantiateAdminRouter(
+		dummyApplication,
+		dummySession,
+		dummySetting,
+		dummyRouter,
+	)
+
+	// assert
+	assert.NotNil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestCreateAdminServer_RouterError(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummySession = &session{id: uuid.New()}
+	var dummySetting = &AdminSetting{}
+	var dummyRouter = chi.NewRouter()
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(instantiateAdminRouter).Expects(dummyApplication, dummySession, dummySetting, dummyRouter).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var result, err = createAdminServer(
+		dummyApplication,
+		dummySession,
+		dummySetting,
+		dummyRouter,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestCreateAdminServer_HappyPath(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummySession = &session{id: uuid.New()}
+	var dummySetting = &AdminSetting{
+		Address: "some address",
+	}
+	var dummyRouter = chi.NewRouter()
+	var dummyAdminRouter = chi.NewRouter()
+	var dummyServer = &hostedServer{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(instantiateAdminRouter).Expects(dummyApplication, dummySession, dummySetting, dummyRouter).Returns(dummyAdminRouter, nil).Once()
+	m.Mock(createServer).Expects(dummySession, ListenerSetting{Name: adminListenerName, Address: "some address"}, dummyAdminRouter).Returns(dummyServer).Once()
+
+	// SUT + act
+	var result, err = createAdminServer(
+		dummyApplication,
+		dummySession,
+		dummySetting,
+		dummyRouter,
+	)
+
+	// assert
+	assert.Equal(t, dummyServer, result)
+	assert.NoError(t, err)
+}
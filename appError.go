@@ -49,9 +49,9 @@ const (
 )
 
 type appError struct {
-	Code        errorCode   `json:"code"`
-	Message     string      `json:"message"`
-	InnerErrors []*appError `json:"innerErrors,omitempty"`
+	Code        errorCode   `json:"code" xml:"code"`
+	Message     string      `json:"message" xml:"message"`
+	InnerErrors []*appError `json:"innerErrors,omitempty" xml:"innerError,omitempty"`
 }
 
 func newAppError(errorCode errorCode, errorMessage string, innerErrors ...error) *appError {
@@ -276,6 +276,24 @@ func GetNotImplemented(errorMessage string, innerErrors ...error) AppError {
 	)
 }
 
+// GetRequestCancelled creates an error related to RequestCancelled
+func GetRequestCancelled(errorMessage string, innerErrors ...error) AppError {
+	return newAppError(
+		errorCodeRequestCancelled,
+		errorMessage,
+		innerErrors...,
+	)
+}
+
+// GetRequestTimeout creates an error related to RequestTimeout
+func GetRequestTimeout(errorMessage string, innerErrors ...error) AppError {
+	return newAppError(
+		errorCodeRequestTimeout,
+		errorMessage,
+		innerErrors...,
+	)
+}
+
 // WrapError wraps the given error with all provided inner errors
 func WrapError(sourceError error, innerErrors ...error) AppError {
 	var typedError, isTyped = sourceError.(AppError)
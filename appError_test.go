@@ -964,6 +964,72 @@ func TestGetNotImplemented(t *testing.T) {
 	assert.Equal(t, dummyResult, appError)
 }
 
+func TestGetRequestCancelled(t *testing.T) {
+	// arrange
+	var dummyErrorMessage = "some error message"
+	var dummyInnerError1 = errors.New("dummy inner error 1")
+	var dummyInnerError2 = errors.New("dummy inner error 2")
+	var dummyInnerError3 = errors.New("dummy inner error 3")
+	var dummyResult = &appError{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(
+		errorCodeRequestCancelled,
+		dummyErrorMessage,
+		dummyInnerError1,
+		dummyInnerError2,
+		dummyInnerError3,
+	).Returns(dummyResult).Once()
+
+	// SUT + act
+	var appError, ok = GetRequestCancelled(
+		dummyErrorMessage,
+		dummyInnerError1,
+		dummyInnerError2,
+		dummyInnerError3,
+	).(*appError)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyResult, appError)
+}
+
+func TestGetRequestTimeout(t *testing.T) {
+	// arrange
+	var dummyErrorMessage = "some error message"
+	var dummyInnerError1 = errors.New("dummy inner error 1")
+	var dummyInnerError2 = errors.New("dummy inner error 2")
+	var dummyInnerError3 = errors.New("dummy inner error 3")
+	var dummyResult = &appError{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(
+		errorCodeRequestTimeout,
+		dummyErrorMessage,
+		dummyInnerError1,
+		dummyInnerError2,
+		dummyInnerError3,
+	).Returns(dummyResult).Once()
+
+	// SUT + act
+	var appError, ok = GetRequestTimeout(
+		dummyErrorMessage,
+		dummyInnerError1,
+		dummyInnerError2,
+		dummyInnerError3,
+	).(*appError)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyResult, appError)
+}
+
 func TestWrapError_NormalError(t *testing.T) {
 	// arrange
 	var dummySourceError = errors.New("some source error")
@@ -2,6 +2,8 @@ package webserver
 
 import (
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -19,14 +21,15 @@ type Application interface {
 }
 
 type application struct {
-	name           string
-	address        string
-	version        string
-	session        *session
-	customization  Customization
-	actionFuncMap  map[string]ActionFunc
-	shutdownSignal chan os.Signal
-	started        bool
+	name             string
+	address          string
+	version          string
+	session          *session
+	customization    Customization
+	actionFuncMap    map[string]ActionFunc
+	clientCertRoutes map[string]bool
+	shutdownSignal   chan os.Signal
+	started          atomic.Bool
 }
 
 // NewApplication creates a new application for web server hosting
@@ -52,11 +55,22 @@ func NewApplication(
 			defaultResponseWriter,
 			map[string]any{},
 			customization,
+			time.Time{},
+			0,
+			nil,
+			nil,
+			false,
+			extractTraceContext(
+				defaultRequest,
+			),
+			nil,
+			nil,
 		},
 		customization,
 		map[string]ActionFunc{},
+		map[string]bool{},
 		make(chan os.Signal),
-		false,
+		atomic.Bool{},
 	}
 	return application
 }
@@ -72,11 +86,11 @@ func (app *application) Session() Session {
 }
 
 func (app *application) IsRunning() bool {
-	return app.started
+	return app.started.Load()
 }
 
 func (app *application) Stop() {
-	if !app.started {
+	if !app.started.Load() {
 		return
 	}
 	haltServer(
@@ -85,7 +99,7 @@ func (app *application) Stop() {
 }
 
 func startApplication(app *application) {
-	if app.started {
+	if app.started.Load() {
 		return
 	}
 	if !preBootstraping(app) {
@@ -130,6 +144,10 @@ func bootstrap(app *application) {
 		app.customization.ClientCert(),
 		app.customization.RoundTripper,
 	)
+	initializeTelemetry(
+		app.customization.TracerProvider(),
+		app.customization.MeterProvider(),
+	)
 	logAppRoot(
 		app.session,
 		LogLevelInfo,
@@ -12,6 +12,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/gomocker/v2"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestNewApplication_NilCustomization(t *testing.T) {
@@ -58,6 +60,7 @@ func TestNewApplication_NilCustomization(t *testing.T) {
 	assert.Equal(t, customizationDefault, value.session.customization)
 	assert.Equal(t, customizationDefault, value.customization)
 	assert.Empty(t, value.actionFuncMap)
+	assert.Empty(t, value.clientCertRoutes)
 	assert.NotZero(t, value.shutdownSignal)
 }
 
@@ -144,22 +147,21 @@ func TestApplication_Session(t *testing.T) {
 func TestApplication_IsRunning(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{
-		name:    "some name",
-		started: rand.IntN(100) > 50,
+		name: "some name",
 	}
+	dummyApplication.started.Store(rand.IntN(100) > 50)
 
 	// SUT + act
 	var result = dummyApplication.IsRunning()
 
 	// assert
-	assert.Equal(t, dummyApplication.started, result)
+	assert.Equal(t, dummyApplication.started.Load(), result)
 }
 
 func TestApplication_Stop_NotStarted(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{
-		name:    "some name",
-		started: false,
+		name: "some name",
 	}
 
 	// SUT + act
@@ -172,8 +174,8 @@ func TestApplication_Stop_HasStarted(t *testing.T) {
 	var dummyApplication = &application{
 		name:           "some name",
 		shutdownSignal: dummyShutdownSignal,
-		started:        true,
 	}
+	dummyApplication.started.Store(true)
 
 	// mock
 	var m = gomocker.NewMocker(t)
@@ -188,9 +190,9 @@ func TestApplication_Stop_HasStarted(t *testing.T) {
 func TestStartApplication_AlreadyStarted(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{
-		name:    "some name",
-		started: true,
+		name: "some name",
 	}
+	dummyApplication.started.Store(true)
 
 	// SUT + act
 	startApplication(dummyApplication)
@@ -321,6 +323,8 @@ func TestBootstrap_HappyPath(t *testing.T) {
 	var dummyWebcallTimeout = time.Duration(rand.IntN(100))
 	var dummySkipCertVerification = rand.IntN(100) > 50
 	var dummyClientCertificate = &tls.Certificate{Certificate: [][]byte{{0}}}
+	var dummyTracerProvider = tracenoop.NewTracerProvider()
+	var dummyMeterProvider = metricnoop.NewMeterProvider()
 	var dummyMessageFormat = "Application bootstrapped successfully"
 
 	// mock
@@ -332,6 +336,9 @@ func TestBootstrap_HappyPath(t *testing.T) {
 	m.Mock((*DefaultCustomization).DefaultTimeout).Expects(dummyCustomization).Returns(dummyWebcallTimeout).Once()
 	m.Mock((*DefaultCustomization).SkipServerCertVerification).Expects(dummyCustomization).Returns(dummySkipCertVerification).Once()
 	m.Mock((*DefaultCustomization).ClientCert).Expects(dummyCustomization).Returns(dummyClientCertificate).Once()
+	m.Mock(initializeTelemetry).Expects(dummyTracerProvider, dummyMeterProvider).Returns().Once()
+	m.Mock((*DefaultCustomization).TracerProvider).Expects(dummyCustomization).Returns(dummyTracerProvider).Once()
+	m.Mock((*DefaultCustomization).MeterProvider).Expects(dummyCustomization).Returns(dummyMeterProvider).Once()
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "application", "bootstrap", dummyMessageFormat).Returns().Once()
 
 	// SUT + act
@@ -413,8 +420,8 @@ func TestBeginApplication_HostError(t *testing.T) {
 		session:        dummySession,
 		customization:  dummyCustomization,
 		shutdownSignal: dummyShutdownSignal,
-		started:        dummyStarted,
 	}
+	dummyApplication.started.Store(dummyStarted)
 	var dummyError = errors.New("some error")
 
 	// mock
@@ -422,7 +429,7 @@ func TestBeginApplication_HostError(t *testing.T) {
 
 	// expect
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "application", "beginApplication", "Trying to start server [%v] (v-%v)", dummyName, dummyVersion).Returns().Once()
-	m.Mock(hostServer).Expects(dummyApplication, dummySession, dummyShutdownSignal, &dummyStarted).Returns(dummyError).Once()
+	m.Mock(hostServer).Expects(dummyApplication, dummySession, dummyShutdownSignal, &dummyApplication.started).Returns(dummyError).Once()
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelError, "application", "beginApplication", "Failed to host server. Error: %+v", dummyError).Returns().Once()
 
 	// SUT + act
@@ -447,15 +454,15 @@ func TestBeginApplication_HostSuccess(t *testing.T) {
 		session:        dummySession,
 		customization:  dummyCustomization,
 		shutdownSignal: dummyShutdownSignal,
-		started:        dummyStarted,
 	}
+	dummyApplication.started.Store(dummyStarted)
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "application", "beginApplication", "Trying to start server [%v] (v-%v)", dummyName, dummyVersion).Returns().Once()
-	m.Mock(hostServer).Expects(dummyApplication, dummySession, dummyShutdownSignal, &dummyStarted).Returns(nil).Once()
+	m.Mock(hostServer).Expects(dummyApplication, dummySession, dummyShutdownSignal, &dummyApplication.started).Returns(nil).Once()
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "application", "beginApplication", "Server hosting terminated").Returns().Once()
 
 	// SUT + act
@@ -0,0 +1,189 @@
+package webserver
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// getBindingSource returns the request source and name a struct field is bound to, according to its "path", "query", "header" or "body" tag
+func getBindingSource(field reflect.StructField) (string, string, bool) {
+	for _, source := range []string{"path", "query", "header", "body"} {
+		var name, found = field.Tag.Lookup(source)
+		if found {
+			return source, name, true
+		}
+	}
+	return "", "", false
+}
+
+func bindRequestValues(session *session, category string, name string, values []string, fieldValue reflect.Value, errorMessage string) error {
+	if len(values) == 0 {
+		return nil
+	}
+	for _, value := range values {
+		logEndpointRequest(
+			session,
+			category,
+			name,
+			"%s",
+			value,
+		)
+	}
+	var unmarshalError = decodeFormField(
+		fieldValue,
+		values,
+		nil,
+	)
+	if unmarshalError != nil {
+		logEndpointRequest(
+			session,
+			category,
+			"UnmarshalError",
+			"%+v",
+			unmarshalError,
+		)
+		return newAppError(
+			errorCodeBadRequest,
+			errorMessage,
+			fmt.Errorf(
+				"unable to bind [%v]: %w",
+				name,
+				unmarshalError,
+			),
+		)
+	}
+	return nil
+}
+
+func bindRequestParameter(session *session, name string, fieldValue reflect.Value) error {
+	var paramValue = chi.URLParam(
+		session.GetRequest(),
+		name,
+	)
+	if paramValue == "" {
+		return newAppError(
+			errorCodeBadRequest,
+			errorMessageParameterNotFound,
+			fmt.Errorf(
+				"unable to bind [%v]",
+				name,
+			),
+		)
+	}
+	return bindRequestValues(
+		session,
+		"Parameter",
+		name,
+		[]string{paramValue},
+		fieldValue,
+		errorMessageParameterInvalid,
+	)
+}
+
+func bindRequestBody(session *session, fieldValue reflect.Value) error {
+	var httpRequest = session.GetRequest()
+	var requestBody = getRequestBody(
+		httpRequest,
+	)
+	if requestBody == "" {
+		return nil
+	}
+	logEndpointRequest(
+		session,
+		"Body",
+		"Content",
+		"%s",
+		requestBody,
+	)
+	var unmarshalError = decodeRequestBody(
+		session,
+		httpRequest,
+		requestBody,
+		fieldValue.Addr().Interface(),
+	)
+	if unmarshalError != nil {
+		logEndpointRequest(
+			session,
+			"Body",
+			"UnmarshalError",
+			"%+v",
+			unmarshalError,
+		)
+		return newAppError(
+			errorCodeBadRequest,
+			errorMessageRequestBodyInvalid,
+			unmarshalError,
+		)
+	}
+	return nil
+}
+
+func bindRequestField(session *session, field reflect.StructField, fieldValue reflect.Value) error {
+	var source, name, found = getBindingSource(
+		field,
+	)
+	if !found {
+		return nil
+	}
+	switch source {
+	case "path":
+		return bindRequestParameter(
+			session,
+			name,
+			fieldValue,
+		)
+	case "query":
+		return bindRequestValues(
+			session,
+			"Query",
+			name,
+			getAllQueries(
+				session,
+				name,
+			),
+			fieldValue,
+			errorMessageQueryInvalid,
+		)
+	case "header":
+		return bindRequestValues(
+			session,
+			"Header",
+			name,
+			getAllHeaders(
+				session,
+				name,
+			),
+			fieldValue,
+			errorMessageHeaderInvalid,
+		)
+	}
+	return bindRequestBody(
+		session,
+		fieldValue,
+	)
+}
+
+// bindRequestFields binds every tagged field of the given struct value from the request, collecting all binding errors instead of stopping at the first one
+func bindRequestFields(session *session, structValue reflect.Value) []error {
+	var bindErrors []error
+	for index := 0; index < structValue.NumField(); index++ {
+		var field = structValue.Type().Field(index)
+		if !field.IsExported() {
+			continue
+		}
+		var bindError = bindRequestField(
+			session,
+			field,
+			structValue.Field(index),
+		)
+		if bindError != nil {
+			bindErrors = append(
+				bindErrors,
+				bindError,
+			)
+		}
+	}
+	return bindErrors
+}
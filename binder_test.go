@@ -0,0 +1,369 @@
+package webserver
+
+import (
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestGetBindingSource(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		None   string
+		Path   string `path:"id" query:"id"`
+		Query  string `query:"page"`
+		Header string `header:"X-Tenant"`
+		Body   string `body:""`
+	}
+	var dummyType = reflect.TypeOf(dummyTemplate{})
+
+	// SUT + act
+	var source0, name0, found0 = getBindingSource(dummyType.Field(0))
+	var source1, name1, found1 = getBindingSource(dummyType.Field(1))
+	var source2, name2, found2 = getBindingSource(dummyType.Field(2))
+	var source3, name3, found3 = getBindingSource(dummyType.Field(3))
+	var source4, name4, found4 = getBindingSource(dummyType.Field(4))
+
+	// assert
+	assert.False(t, found0)
+	assert.Empty(t, source0)
+	assert.Empty(t, name0)
+	assert.True(t, found1)
+	assert.Equal(t, "path", source1)
+	assert.Equal(t, "id", name1)
+	assert.True(t, found2)
+	assert.Equal(t, "query", source2)
+	assert.Equal(t, "page", name2)
+	assert.True(t, found3)
+	assert.Equal(t, "header", source3)
+	assert.Equal(t, "X-Tenant", name3)
+	assert.True(t, found4)
+	assert.Equal(t, "body", source4)
+	assert.Empty(t, name4)
+}
+
+func TestBindRequestValues_NoValues(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyField = rand.Int()
+	var dummyFieldCopy = dummyField
+
+	// SUT + act
+	var err = bindRequestValues(
+		dummySession,
+		"some category",
+		"some name",
+		nil,
+		reflect.ValueOf(&dummyField).Elem(),
+		"some error message",
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyFieldCopy, dummyField)
+}
+
+func TestBindRequestValues_UnmarshalError(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyCategory = "some category"
+	var dummyName = "some name"
+	var dummyValues = []string{"123", "abc"}
+	var dummyField []int
+	var dummyErrorMessage = "some error message"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logEndpointRequest).Expects(dummySession, dummyCategory, dummyName, "%s", "123").Returns().Once()
+	m.Mock(logEndpointRequest).Expects(dummySession, dummyCategory, dummyName, "%s", "abc").Returns().Once()
+	m.Mock(logEndpointRequest).Expects(dummySession, dummyCategory, "UnmarshalError", "%+v", gomocker.Anything()).Returns().Once()
+
+	// SUT + act
+	var err = bindRequestValues(
+		dummySession,
+		dummyCategory,
+		dummyName,
+		dummyValues,
+		reflect.ValueOf(&dummyField).Elem(),
+		dummyErrorMessage,
+	)
+
+	// assert
+	assert.EqualError(t, err, "(BadRequest) some error message -> [ (GeneralFailure) unable to bind [some name]: unable to unmarshal value [abc] into data template ]")
+	assert.Nil(t, dummyField)
+}
+
+func TestBindRequestValues_Success(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyCategory = "some category"
+	var dummyName = "some name"
+	var dummyValues = []string{"123", "456"}
+	var dummyField []int
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logEndpointRequest).Expects(dummySession, dummyCategory, dummyName, "%s", "123").Returns().Once()
+	m.Mock(logEndpointRequest).Expects(dummySession, dummyCategory, dummyName, "%s", "456").Returns().Once()
+
+	// SUT + act
+	var err = bindRequestValues(
+		dummySession,
+		dummyCategory,
+		dummyName,
+		dummyValues,
+		reflect.ValueOf(&dummyField).Elem(),
+		"some error message",
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int{123, 456}, dummyField)
+}
+
+func TestBindRequestParameter_NotFound(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySession = &session{
+		request: dummyHTTPRequest,
+	}
+	var dummyName = "some name"
+	var dummyField int
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(chi.URLParam).Expects(dummyHTTPRequest, dummyName).Returns("").Once()
+
+	// SUT + act
+	var err = bindRequestParameter(
+		dummySession,
+		dummyName,
+		reflect.ValueOf(&dummyField).Elem(),
+	)
+
+	// assert
+	assert.EqualError(t, err, "(BadRequest) The request parameter is not found -> [ (GeneralFailure) unable to bind [some name] ]")
+}
+
+func TestBindRequestParameter_Found(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySession = &session{
+		request: dummyHTTPRequest,
+	}
+	var dummyName = "some name"
+	var dummyValue = "some value"
+	var dummyField int
+	var dummyFieldValue = reflect.ValueOf(&dummyField).Elem()
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(chi.URLParam).Expects(dummyHTTPRequest, dummyName).Returns(dummyValue).Once()
+	m.Mock(bindRequestValues).Expects(dummySession, "Parameter", dummyName, []string{dummyValue}, dummyFieldValue, errorMessageParameterInvalid).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = bindRequestParameter(
+		dummySession,
+		dummyName,
+		dummyFieldValue,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestBindRequestBody_Empty(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySession = &session{
+		request: dummyHTTPRequest,
+	}
+	var dummyField int
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRequestBody).Expects(dummyHTTPRequest).Returns("").Once()
+
+	// SUT + act
+	var err = bindRequestBody(
+		dummySession,
+		reflect.ValueOf(&dummyField).Elem(),
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestBindRequestBody_Invalid(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySession = &session{
+		request: dummyHTTPRequest,
+	}
+	var dummyRequestBody = "some request body"
+	var dummyField int
+	var dummyError = errors.New("some error")
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRequestBody).Expects(dummyHTTPRequest).Returns(dummyRequestBody).Once()
+	m.Mock(logEndpointRequest).Expects(dummySession, "Body", "Content", "%s", dummyRequestBody).Returns().Once()
+	m.Mock(decodeRequestBody).Expects(dummySession, dummyHTTPRequest, dummyRequestBody, &dummyField).Returns(dummyError).Once()
+	m.Mock(logEndpointRequest).Expects(dummySession, "Body", "UnmarshalError", "%+v", dummyError).Returns().Once()
+	m.Mock(newAppError).Expects(errorCodeBadRequest, errorMessageRequestBodyInvalid, dummyError).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = bindRequestBody(
+		dummySession,
+		reflect.ValueOf(&dummyField).Elem(),
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestBindRequestBody_Valid(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{}
+	var dummySession = &session{
+		request: dummyHTTPRequest,
+	}
+	var dummyRequestBody = "some request body"
+	var dummyField int
+	var dummyResult = rand.Int()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRequestBody).Expects(dummyHTTPRequest).Returns(dummyRequestBody).Once()
+	m.Mock(logEndpointRequest).Expects(dummySession, "Body", "Content", "%s", dummyRequestBody).Returns().Once()
+	m.Mock(decodeRequestBody).Expects(dummySession, dummyHTTPRequest, dummyRequestBody, &dummyField).Returns(nil).SideEffects(
+		gomocker.ParamSideEffect(1, 4, func(value *int) { *value = dummyResult })).Once()
+
+	// SUT + act
+	var err = bindRequestBody(
+		dummySession,
+		reflect.ValueOf(&dummyField).Elem(),
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyResult, dummyField)
+}
+
+func TestBindRequestField_NotBound(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Foo int
+	}
+	var dummySession = &session{}
+	var dummyDataTemplate dummyTemplate
+
+	// SUT + act
+	var err = bindRequestField(
+		dummySession,
+		reflect.TypeOf(dummyDataTemplate).Field(0),
+		reflect.ValueOf(&dummyDataTemplate).Elem().Field(0),
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestBindRequestField_Sources(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Path   int      `path:"id"`
+		Query  []string `query:"page"`
+		Header string   `header:"X-Tenant"`
+		Body   any      `body:""`
+	}
+	var dummySession = &session{}
+	var dummyDataTemplate dummyTemplate
+	var dummyType = reflect.TypeOf(dummyDataTemplate)
+	var dummyValue = reflect.ValueOf(&dummyDataTemplate).Elem()
+	var dummyQueries = []string{"some query"}
+	var dummyHeaders = []string{"some header"}
+	var dummyError1 = errors.New("some error 1")
+	var dummyError2 = errors.New("some error 2")
+	var dummyError3 = errors.New("some error 3")
+	var dummyError4 = errors.New("some error 4")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(bindRequestParameter).Expects(dummySession, "id", dummyValue.Field(0)).Returns(dummyError1).Once()
+	m.Mock(getAllQueries).Expects(dummySession, "page").Returns(dummyQueries).Once()
+	m.Mock(bindRequestValues).Expects(dummySession, "Query", "page", dummyQueries, dummyValue.Field(1), errorMessageQueryInvalid).Returns(dummyError2).Once()
+	m.Mock(getAllHeaders).Expects(dummySession, "X-Tenant").Returns(dummyHeaders).Once()
+	m.Mock(bindRequestValues).Expects(dummySession, "Header", "X-Tenant", dummyHeaders, dummyValue.Field(2), errorMessageHeaderInvalid).Returns(dummyError3).Once()
+	m.Mock(bindRequestBody).Expects(dummySession, dummyValue.Field(3)).Returns(dummyError4).Once()
+
+	// SUT + act
+	var err1 = bindRequestField(dummySession, dummyType.Field(0), dummyValue.Field(0))
+	var err2 = bindRequestField(dummySession, dummyType.Field(1), dummyValue.Field(1))
+	var err3 = bindRequestField(dummySession, dummyType.Field(2), dummyValue.Field(2))
+	var err4 = bindRequestField(dummySession, dummyType.Field(3), dummyValue.Field(3))
+
+	// assert
+	assert.Equal(t, dummyError1, err1)
+	assert.Equal(t, dummyError2, err2)
+	assert.Equal(t, dummyError3, err3)
+	assert.Equal(t, dummyError4, err4)
+}
+
+func TestBindRequestFields(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Foo     int `query:"foo"`
+		Bar     int `query:"bar"`
+		Test    int `query:"test"`
+		private int `query:"private"`
+	}
+	var dummySession = &session{}
+	var dummyDataTemplate dummyTemplate
+	var dummyType = reflect.TypeOf(dummyDataTemplate)
+	var dummyValue = reflect.ValueOf(&dummyDataTemplate).Elem()
+	var dummyError1 = errors.New("some error 1")
+	var dummyError3 = errors.New("some error 3")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(bindRequestField).Expects(dummySession, dummyType.Field(0), dummyValue.Field(0)).Returns(dummyError1).Once()
+	m.Mock(bindRequestField).Expects(dummySession, dummyType.Field(1), dummyValue.Field(1)).Returns(nil).Once()
+	m.Mock(bindRequestField).Expects(dummySession, dummyType.Field(2), dummyValue.Field(2)).Returns(dummyError3).Once()
+
+	// SUT + act
+	var result = bindRequestFields(
+		dummySession,
+		dummyValue,
+	)
+
+	// assert
+	assert.Equal(t, []error{dummyError1, dummyError3}, result)
+	assert.Zero(t, dummyDataTemplate.private)
+}
@@ -0,0 +1,237 @@
+package webserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// defaultCertificateCheckInterval is the interval of checking the PEM files for changes when not specified
+const defaultCertificateCheckInterval = time.Minute
+
+// CertificateProvider provides the server certificate and CA cert pool on each TLS handshake, so that rotated certificates take effect without restarting the server
+type CertificateProvider interface {
+	// Certificate returns the current server certificate
+	Certificate() (*tls.Certificate, error)
+	// CaCertPool returns the current CA cert pool for incoming client certificate validation; if nil, no validation is conducted for incoming client certificates
+	CaCertPool() (*x509.CertPool, error)
+}
+
+// sessionBinder is implemented by built-in certificate providers that log through the application session once bound to a server
+type sessionBinder interface {
+	bindSession(session *session)
+}
+
+// staticCertificateProvider provides the fixed server certificate and CA cert pool given by customization
+type staticCertificateProvider struct {
+	serverCert *tls.Certificate
+	caCertPool *x509.CertPool
+}
+
+func (provider *staticCertificateProvider) Certificate() (*tls.Certificate, error) {
+	return provider.serverCert, nil
+}
+
+func (provider *staticCertificateProvider) CaCertPool() (*x509.CertPool, error) {
+	return provider.caCertPool, nil
+}
+
+// fileCertificateProvider provides the server certificate and CA cert pool loaded from PEM files, reloading them once the files change
+type fileCertificateProvider struct {
+	certFile      string
+	keyFile       string
+	caFile        string
+	checkInterval time.Duration
+	session       *session
+	lock          sync.RWMutex
+	serverCert    *tls.Certificate
+	caCertPool    *x509.CertPool
+	modTime       time.Time
+	lastChecked   time.Time
+}
+
+// NewFileCertificateProvider creates a certificate provider loading the server certificate and key, as well as the CA certificates if the CA file is not empty, from the given PEM files; the files are checked for changes at most once per check interval (1 minute if 0 or negative) on TLS handshakes, and each rotation is logged
+func NewFileCertificateProvider(
+	certFile string,
+	keyFile string,
+	caFile string,
+	checkInterval time.Duration,
+) (CertificateProvider, error) {
+	if checkInterval <= 0 {
+		checkInterval = defaultCertificateCheckInterval
+	}
+	var provider = &fileCertificateProvider{
+		certFile:      certFile,
+		keyFile:       keyFile,
+		caFile:        caFile,
+		checkInterval: checkInterval,
+	}
+	var modTime, statError = provider.getModTime()
+	if statError != nil {
+		return nil, statError
+	}
+	var loadError = provider.load(
+		modTime,
+	)
+	if loadError != nil {
+		return nil, loadError
+	}
+	return provider, nil
+}
+
+func (provider *fileCertificateProvider) bindSession(session *session) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	provider.session = session
+}
+
+func (provider *fileCertificateProvider) files() []string {
+	if provider.caFile == "" {
+		return []string{provider.certFile, provider.keyFile}
+	}
+	return []string{provider.certFile, provider.keyFile, provider.caFile}
+}
+
+// getModTime returns the latest modification time among all PEM files
+func (provider *fileCertificateProvider) getModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range provider.files() {
+		var fileInfo, statError = os.Stat(file)
+		if statError != nil {
+			return modTime, statError
+		}
+		if fileInfo.ModTime().After(modTime) {
+			modTime = fileInfo.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func loadCaCertPool(caFile string) (*x509.CertPool, error) {
+	if caFile == "" {
+		return nil, nil
+	}
+	var caCerts, readError = os.ReadFile(caFile)
+	if readError != nil {
+		return nil, readError
+	}
+	var caCertPool = x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCerts) {
+		return nil, errors.New("no CA certificate found in " + caFile)
+	}
+	return caCertPool, nil
+}
+
+// load loads all PEM files, replacing the current certificates only if all of them are loaded successfully
+func (provider *fileCertificateProvider) load(modTime time.Time) error {
+	var serverCert, certError = tls.LoadX509KeyPair(
+		provider.certFile,
+		provider.keyFile,
+	)
+	if certError != nil {
+		return certError
+	}
+	var caCertPool, caError = loadCaCertPool(
+		provider.caFile,
+	)
+	if caError != nil {
+		return caError
+	}
+	provider.serverCert = &serverCert
+	provider.caCertPool = caCertPool
+	provider.modTime = modTime
+	return nil
+}
+
+// refresh reloads the PEM files if they have changed since last loaded, keeping the current certificates if the reload fails
+func (provider *fileCertificateProvider) refresh() {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	var now = getTimeNowUTC()
+	if now.Sub(provider.lastChecked) < provider.checkInterval {
+		return
+	}
+	provider.lastChecked = now
+	var modTime, statError = provider.getModTime()
+	if statError == nil && modTime.Equal(provider.modTime) {
+		return
+	}
+	var reloadError = statError
+	if reloadError == nil {
+		reloadError = provider.load(
+			modTime,
+		)
+	}
+	if reloadError != nil {
+		logAppRoot(
+			provider.session,
+			LogLevelWarn,
+			"certificate",
+			"refresh",
+			"Failed to reload certificates from %v: %+v",
+			provider.files(),
+			reloadError,
+		)
+		return
+	}
+	logAppRoot(
+		provider.session,
+		LogLevelInfo,
+		"certificate",
+		"refresh",
+		"Certificates rotated from %v modified at %v",
+		provider.files(),
+		modTime,
+	)
+}
+
+func (provider *fileCertificateProvider) Certificate() (*tls.Certificate, error) {
+	provider.refresh()
+	provider.lock.RLock()
+	defer provider.lock.RUnlock()
+	return provider.serverCert, nil
+}
+
+func (provider *fileCertificateProvider) CaCertPool() (*x509.CertPool, error) {
+	provider.refresh()
+	provider.lock.RLock()
+	defer provider.lock.RUnlock()
+	return provider.caCertPool, nil
+}
+
+// getCertificateProvider returns the customized certificate provider of the listener, or a provider of its fixed server certificate and CA cert pool; nil if the listener is not hosted with HTTPS
+func getCertificateProvider(setting ListenerSetting) CertificateProvider {
+	if !isInterfaceValueNil(setting.CertificateProvider) {
+		return setting.CertificateProvider
+	}
+	if setting.ServerCert == nil {
+		return nil
+	}
+	return &staticCertificateProvider{
+		serverCert: setting.ServerCert,
+		caCertPool: setting.CaCertPool,
+	}
+}
+
+// getClientConfig returns the TLS config for a new handshake with the current CA cert pool of the certificate provider
+func getClientConfig(
+	tlsConfig *tls.Config,
+	provider CertificateProvider,
+	clientAuthMode ClientAuthMode,
+) (*tls.Config, error) {
+	var caCertPool, poolError = provider.CaCertPool()
+	if poolError != nil {
+		return nil, poolError
+	}
+	var clientConfig = tlsConfig.Clone()
+	clientConfig.GetConfigForClient = nil
+	clientConfig.ClientAuth = getTLSClientAuth(
+		clientAuthMode,
+		caCertPool != nil,
+	)
+	clientConfig.ClientCAs = caCertPool
+	return clientConfig, nil
+}
@@ -0,0 +1,428 @@
+package webserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+// writeTestCertificate writes a self-signed certificate of the given common name and its key as PEM files into the given directory
+func writeTestCertificate(t *testing.T, directory string, commonName string) (string, string) {
+	var privateKey, keyError = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, keyError)
+	var template = &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:              []string{commonName},
+	}
+	var certBytes, certError = x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	assert.NoError(t, certError)
+	var keyBytes, marshalError = x509.MarshalECPrivateKey(privateKey)
+	assert.NoError(t, marshalError)
+	var certFile = filepath.Join(directory, "cert.pem")
+	var keyFile = filepath.Join(directory, "key.pem")
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), 0600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	return certFile, keyFile
+}
+
+// getCommonName returns the common name of the leaf of the given certificate
+func getCommonName(t *testing.T, serverCert *tls.Certificate) string {
+	var leaf, parseError = x509.ParseCertificate(serverCert.Certificate[0])
+	assert.NoError(t, parseError)
+	return leaf.Subject.CommonName
+}
+
+func TestStaticCertificateProvider(t *testing.T) {
+	// arrange
+	var dummyServerCert = &tls.Certificate{}
+	var dummyCaCertPool = &x509.CertPool{}
+	var sut = &staticCertificateProvider{
+		serverCert: dummyServerCert,
+		caCertPool: dummyCaCertPool,
+	}
+
+	// act
+	var serverCert, certError = sut.Certificate()
+	var caCertPool, poolError = sut.CaCertPool()
+
+	// assert
+	assert.Equal(t, dummyServerCert, serverCert)
+	assert.NoError(t, certError)
+	assert.Equal(t, dummyCaCertPool, caCertPool)
+	assert.NoError(t, poolError)
+}
+
+func TestNewFileCertificateProvider_StatError(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+
+	// SUT + act
+	var result, err = NewFileCertificateProvider(
+		filepath.Join(directory, "cert.pem"),
+		filepath.Join(directory, "key.pem"),
+		"",
+		0,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewFileCertificateProvider_LoadError(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var certFile, _ = writeTestCertificate(t, directory, "server")
+
+	// SUT + act
+	var result, err = NewFileCertificateProvider(
+		certFile,
+		certFile,
+		"",
+		0,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestNewFileCertificateProvider_HappyPath(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var certFile, keyFile = writeTestCertificate(t, directory, "server")
+
+	// SUT + act
+	var result, err = NewFileCertificateProvider(
+		certFile,
+		keyFile,
+		certFile,
+		0,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	var provider, ok = result.(*fileCertificateProvider)
+	assert.True(t, ok)
+	assert.Equal(t, defaultCertificateCheckInterval, provider.checkInterval)
+	assert.Equal(t, "server", getCommonName(t, provider.serverCert))
+	assert.NotNil(t, provider.caCertPool)
+	assert.Equal(t, []string{certFile, keyFile, certFile}, provider.files())
+}
+
+func TestLoadCaCertPool_NoFile(t *testing.T) {
+	// SUT + act
+	var result, err = loadCaCertPool(
+		"",
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.NoError(t, err)
+}
+
+func TestLoadCaCertPool_ReadError(t *testing.T) {
+	// SUT + act
+	var result, err = loadCaCertPool(
+		filepath.Join(t.TempDir(), "ca.pem"),
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoadCaCertPool_NoCertificate(t *testing.T) {
+	// arrange
+	var caFile = filepath.Join(t.TempDir(), "ca.pem")
+	assert.NoError(t, os.WriteFile(caFile, []byte("not a certificate"), 0600))
+
+	// SUT + act
+	var result, err = loadCaCertPool(
+		caFile,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "no CA certificate found in "+caFile)
+}
+
+func TestFileCertificateProvider_Load_CaError(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var certFile, keyFile = writeTestCertificate(t, directory, "server")
+	var sut = &fileCertificateProvider{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   keyFile,
+	}
+
+	// act
+	var err = sut.load(
+		time.Now(),
+	)
+
+	// assert
+	assert.Error(t, err)
+	assert.Nil(t, sut.serverCert)
+}
+
+func TestFileCertificateProvider_Refresh_WithinInterval(t *testing.T) {
+	// arrange
+	var dummyNow = time.Now().UTC()
+	var sut = &fileCertificateProvider{
+		certFile:      "some cert file",
+		keyFile:       "some key file",
+		checkInterval: time.Minute,
+		lastChecked:   dummyNow.Add(-time.Second),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyNow).Once()
+
+	// act
+	sut.refresh()
+
+	// assert
+	assert.Equal(t, dummyNow.Add(-time.Second), sut.lastChecked)
+}
+
+func TestFileCertificateProvider_Refresh_Unchanged(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var certFile, keyFile = writeTestCertificate(t, directory, "server")
+	var provider, _ = NewFileCertificateProvider(certFile, keyFile, "", time.Minute)
+	var sut = provider.(*fileCertificateProvider)
+	var dummyServerCert = sut.serverCert
+
+	// act
+	sut.refresh()
+
+	// assert
+	assert.False(t, sut.lastChecked.IsZero())
+	assert.Equal(t, dummyServerCert, sut.serverCert)
+}
+
+func TestFileCertificateProvider_Refresh_Rotated(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var certFile, keyFile = writeTestCertificate(t, directory, "old")
+	var provider, _ = NewFileCertificateProvider(certFile, keyFile, certFile, time.Minute)
+	var sut = provider.(*fileCertificateProvider)
+	var dummySession = &session{id: uuid.New()}
+	sut.bindSession(dummySession)
+	writeTestCertificate(t, directory, "new")
+	var modTime = time.Now().Add(time.Hour)
+	assert.NoError(t, os.Chtimes(certFile, modTime, modTime))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "certificate", "refresh", "Certificates rotated from %v modified at %v",
+		[]string{certFile, keyFile, certFile}, gomocker.Anything()).Returns().Once()
+
+	// act
+	var serverCert, certError = sut.Certificate()
+	var caCertPool, poolError = sut.CaCertPool()
+
+	// assert
+	assert.Equal(t, "new", getCommonName(t, serverCert))
+	assert.NoError(t, certError)
+	assert.NotNil(t, caCertPool)
+	assert.NoError(t, poolError)
+}
+
+func TestFileCertificateProvider_Refresh_ReloadError(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var certFile, keyFile = writeTestCertificate(t, directory, "old")
+	var provider, _ = NewFileCertificateProvider(certFile, keyFile, "", time.Minute)
+	var sut = provider.(*fileCertificateProvider)
+	var dummySession = &session{id: uuid.New()}
+	sut.bindSession(dummySession)
+	assert.NoError(t, os.WriteFile(keyFile, []byte("partially written"), 0600))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelWarn, "certificate", "refresh", "Failed to reload certificates from %v: %+v",
+		[]string{certFile, keyFile}, gomocker.Anything()).Returns().Once()
+
+	// act
+	var serverCert, certError = sut.Certificate()
+
+	// assert
+	assert.Equal(t, "old", getCommonName(t, serverCert))
+	assert.NoError(t, certError)
+}
+
+func TestFileCertificateProvider_Refresh_StatError(t *testing.T) {
+	// arrange
+	var directory = t.TempDir()
+	var certFile, keyFile = writeTestCertificate(t, directory, "old")
+	var provider, _ = NewFileCertificateProvider(certFile, keyFile, "", time.Minute)
+	var sut = provider.(*fileCertificateProvider)
+	var dummySession = &session{id: uuid.New()}
+	sut.bindSession(dummySession)
+	assert.NoError(t, os.Remove(keyFile))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelWarn, "certificate", "refresh", "Failed to reload certificates from %v: %+v",
+		[]string{certFile, keyFile}, gomocker.Anything()).Returns().Once()
+
+	// act
+	var serverCert, certError = sut.Certificate()
+
+	// assert
+	assert.Equal(t, "old", getCommonName(t, serverCert))
+	assert.NoError(t, certError)
+}
+
+func TestGetCertificateProvider_Customized(t *testing.T) {
+	// arrange
+	var dummyProvider = &staticCertificateProvider{}
+	var dummySetting = ListenerSetting{
+		ServerCert:          &tls.Certificate{},
+		CertificateProvider: dummyProvider,
+	}
+
+	// SUT + act
+	var result = getCertificateProvider(
+		dummySetting,
+	)
+
+	// assert
+	assert.Equal(t, dummyProvider, result)
+}
+
+func TestGetCertificateProvider_NoServerCert(t *testing.T) {
+	// arrange
+	var dummySetting = ListenerSetting{
+		CaCertPool: &x509.CertPool{},
+	}
+
+	// SUT + act
+	var result = getCertificateProvider(
+		dummySetting,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestGetCertificateProvider_ServerCert(t *testing.T) {
+	// arrange
+	var dummySetting = ListenerSetting{
+		ServerCert: &tls.Certificate{},
+		CaCertPool: &x509.CertPool{},
+	}
+
+	// SUT + act
+	var result = getCertificateProvider(
+		dummySetting,
+	)
+
+	// assert
+	assert.Equal(t, &staticCertificateProvider{
+		serverCert: dummySetting.ServerCert,
+		caCertPool: dummySetting.CaCertPool,
+	}, result)
+}
+
+func TestGetClientConfig_PoolError(t *testing.T) {
+	// arrange
+	var dummyTLSConfig = &tls.Config{}
+	var dummyProvider = &fileCertificateProvider{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*fileCertificateProvider).CaCertPool).Expects(dummyProvider).Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var result, err = getClientConfig(
+		dummyTLSConfig,
+		dummyProvider,
+		ClientAuthRequire,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestGetClientConfig_NoPool(t *testing.T) {
+	// arrange
+	var dummyTLSConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) { return nil, nil },
+	}
+	var dummyProvider = &staticCertificateProvider{}
+
+	// SUT + act
+	var result, err = getClientConfig(
+		dummyTLSConfig,
+		dummyProvider,
+		ClientAuthRequire,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), result.MinVersion)
+	assert.Nil(t, result.GetConfigForClient)
+	assert.Equal(t, tls.RequireAnyClientCert, result.ClientAuth)
+	assert.Nil(t, result.ClientCAs)
+	assert.NotNil(t, dummyTLSConfig.GetConfigForClient)
+}
+
+func TestGetClientConfig_WithPool(t *testing.T) {
+	// arrange
+	var dummyTLSConfig = &tls.Config{}
+	var dummyCaCertPool = &x509.CertPool{}
+	var dummyProvider = &staticCertificateProvider{
+		caCertPool: dummyCaCertPool,
+	}
+
+	// SUT + act
+	var result, err = getClientConfig(
+		dummyTLSConfig,
+		dummyProvider,
+		ClientAuthVerifyIfGiven,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, result.ClientAuth)
+	assert.Equal(t, dummyCaCertPool, result.ClientCAs)
+}
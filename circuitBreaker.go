@@ -0,0 +1,249 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// These are the default values used when the corresponding CircuitBreakerSetting fields are not set
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitSuccessThreshold = 1
+	defaultCircuitOpenDuration     = 30 * time.Second
+)
+
+// CircuitBreakerSetting holds the circuit breaker configuration applied to a webcall
+type CircuitBreakerSetting struct {
+	// Key identifies the circuit a webcall belongs to, e.g. host name or host name plus route; webcalls sharing the same key share the same circuit state; if empty, the host of the webcall URL is used
+	Key string
+	// FailureThreshold is the number of consecutive failed webcalls for the circuit to open; if not set, defaults to 5
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive successful trial webcalls in half-open state for the circuit to close again; if not set, defaults to 1
+	SuccessThreshold int
+	// OpenDuration is how long the circuit stays open before allowing trial webcalls in half-open state; if not set, defaults to 30 seconds
+	OpenDuration time.Duration
+	// FailureStatusCodes are the ranges of HTTP status codes to be considered as failures besides connectivity errors; if not set, all 5xx status codes are considered as failures
+	FailureStatusCodes []StatusCodeRange
+}
+
+type circuitState int
+
+// These are the enum definitions of circuit states
+const (
+	circuitStateClosed circuitState = iota
+	circuitStateOpen
+	circuitStateHalfOpen
+)
+
+func (state circuitState) String() string {
+	switch state {
+	case circuitStateOpen:
+		return "Open"
+	case circuitStateHalfOpen:
+		return "HalfOpen"
+	}
+	return "Closed"
+}
+
+type circuitBreaker struct {
+	state         circuitState
+	failures      int
+	successes     int
+	openedAt      time.Time
+	trialInFlight bool
+}
+
+var (
+	circuitBreakers     = map[string]*circuitBreaker{}
+	circuitBreakersLock = &sync.Mutex{}
+)
+
+func getCircuitKey(setting *CircuitBreakerSetting, httpRequest *http.Request) string {
+	if setting.Key != "" {
+		return setting.Key
+	}
+	if httpRequest == nil ||
+		httpRequest.URL == nil {
+		return ""
+	}
+	return httpRequest.URL.Host
+}
+
+func getCircuitBreaker(key string) *circuitBreaker {
+	var breaker, found = circuitBreakers[key]
+	if !found {
+		breaker = &circuitBreaker{}
+		circuitBreakers[key] = breaker
+	}
+	return breaker
+}
+
+func logCircuitStateChange(session *session, key string, oldState circuitState, newState circuitState) {
+	if oldState == newState {
+		return
+	}
+	var logLevel = LogLevelInfo
+	if newState == circuitStateOpen {
+		logLevel = LogLevelWarn
+	}
+	logMethodLogic(
+		session,
+		logLevel,
+		"CircuitBreaker",
+		key,
+		"Circuit state changed from %v to %v",
+		oldState,
+		newState,
+	)
+}
+
+func acquireCircuit(setting *CircuitBreakerSetting, key string) (circuitState, circuitState, bool) {
+	circuitBreakersLock.Lock()
+	defer circuitBreakersLock.Unlock()
+	var breaker = getCircuitBreaker(key)
+	var oldState = breaker.state
+	if breaker.state == circuitStateOpen {
+		var openDuration = setting.OpenDuration
+		if openDuration <= 0 {
+			openDuration = defaultCircuitOpenDuration
+		}
+		if getTimeNowUTC().Sub(breaker.openedAt) < openDuration {
+			return oldState, breaker.state, false
+		}
+		breaker.state = circuitStateHalfOpen
+		breaker.successes = 0
+		breaker.trialInFlight = false
+	}
+	if breaker.state == circuitStateHalfOpen {
+		if breaker.trialInFlight {
+			return oldState, breaker.state, false
+		}
+		breaker.trialInFlight = true
+	}
+	return oldState, breaker.state, true
+}
+
+// allowCircuitRequest checks whether the circuit of the given webcall allows it to be sent over the wire
+func allowCircuitRequest(session *session, setting *CircuitBreakerSetting, httpRequest *http.Request) error {
+	if setting == nil {
+		return nil
+	}
+	var key = getCircuitKey(
+		setting,
+		httpRequest,
+	)
+	var oldState, newState, allowed = acquireCircuit(
+		setting,
+		key,
+	)
+	logCircuitStateChange(
+		session,
+		key,
+		oldState,
+		newState,
+	)
+	if allowed {
+		return nil
+	}
+	return newAppError(
+		errorCodeCircuitBreak,
+		fmt.Sprintf(
+			"The circuit for [%v] is %v",
+			key,
+			newState,
+		),
+	)
+}
+
+func isCircuitFailure(setting *CircuitBreakerSetting, responseObject *http.Response, responseError error) bool {
+	if responseError != nil {
+		return true
+	}
+	if responseObject == nil {
+		return false
+	}
+	if len(setting.FailureStatusCodes) == 0 {
+		return responseObject.StatusCode >= http.StatusInternalServerError
+	}
+	for _, codeRange := range setting.FailureStatusCodes {
+		if codeRange.Begin <= responseObject.StatusCode &&
+			codeRange.End > responseObject.StatusCode {
+			return true
+		}
+	}
+	return false
+}
+
+func releaseCircuit(setting *CircuitBreakerSetting, key string, failed bool, cancelled bool) (circuitState, circuitState) {
+	circuitBreakersLock.Lock()
+	defer circuitBreakersLock.Unlock()
+	var breaker = getCircuitBreaker(key)
+	var oldState = breaker.state
+	if breaker.state == circuitStateHalfOpen {
+		breaker.trialInFlight = false
+	}
+	if cancelled {
+		return oldState, breaker.state
+	}
+	if failed {
+		breaker.successes = 0
+		breaker.failures++
+		var failureThreshold = setting.FailureThreshold
+		if failureThreshold <= 0 {
+			failureThreshold = defaultCircuitFailureThreshold
+		}
+		if breaker.state == circuitStateHalfOpen ||
+			breaker.failures >= failureThreshold {
+			breaker.state = circuitStateOpen
+			breaker.openedAt = getTimeNowUTC()
+		}
+		return oldState, breaker.state
+	}
+	breaker.failures = 0
+	if breaker.state == circuitStateHalfOpen {
+		breaker.successes++
+		var successThreshold = setting.SuccessThreshold
+		if successThreshold <= 0 {
+			successThreshold = defaultCircuitSuccessThreshold
+		}
+		if breaker.successes >= successThreshold {
+			breaker.state = circuitStateClosed
+			breaker.successes = 0
+		}
+	}
+	return oldState, breaker.state
+}
+
+// recordCircuitResult updates the circuit of the given webcall according to its outcome
+func recordCircuitResult(session *session, setting *CircuitBreakerSetting, httpRequest *http.Request, responseObject *http.Response, responseError error) {
+	if setting == nil {
+		return
+	}
+	var key = getCircuitKey(
+		setting,
+		httpRequest,
+	)
+	var oldState, newState = releaseCircuit(
+		setting,
+		key,
+		isCircuitFailure(
+			setting,
+			responseObject,
+			responseError,
+		),
+		errors.Is(
+			responseError,
+			context.Canceled,
+		),
+	)
+	logCircuitStateChange(
+		session,
+		key,
+		oldState,
+		newState,
+	)
+}
@@ -0,0 +1,639 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestCircuitStateString(t *testing.T) {
+	// assert
+	assert.Equal(t, "Closed", circuitStateClosed.String())
+	assert.Equal(t, "Open", circuitStateOpen.String())
+	assert.Equal(t, "HalfOpen", circuitStateHalfOpen.String())
+	assert.Equal(t, "Closed", circuitState(rand.IntN(100)+3).String())
+}
+
+func TestGetCircuitKey_SettingKey(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{Key: "some key"}
+	var dummyHTTPRequest = &http.Request{URL: &url.URL{Host: "some host"}}
+
+	// SUT + act
+	var result = getCircuitKey(
+		dummySetting,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, "some key", result)
+}
+
+func TestGetCircuitKey_NoURL(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyHTTPRequest = &http.Request{}
+
+	// SUT + act
+	var result = getCircuitKey(
+		dummySetting,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestGetCircuitKey_URLHost(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyHTTPRequest = &http.Request{URL: &url.URL{Host: "some host"}}
+
+	// SUT + act
+	var result = getCircuitKey(
+		dummySetting,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, "some host", result)
+}
+
+func TestGetCircuitBreaker_Existing(t *testing.T) {
+	// arrange
+	var dummyKey = "some key"
+	var dummyBreaker = &circuitBreaker{failures: rand.Int()}
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: dummyBreaker,
+	}
+
+	// SUT + act
+	var result = getCircuitBreaker(
+		dummyKey,
+	)
+
+	// assert
+	assert.Equal(t, dummyBreaker, result)
+}
+
+func TestGetCircuitBreaker_New(t *testing.T) {
+	// arrange
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{}
+
+	// SUT + act
+	var result = getCircuitBreaker(
+		dummyKey,
+	)
+
+	// assert
+	assert.Equal(t, &circuitBreaker{}, result)
+	assert.Equal(t, result, circuitBreakers[dummyKey])
+}
+
+func TestLogCircuitStateChange_NoChange(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyKey = "some key"
+
+	// SUT + act
+	logCircuitStateChange(
+		dummySession,
+		dummyKey,
+		circuitStateOpen,
+		circuitStateOpen,
+	)
+}
+
+func TestLogCircuitStateChange_Open(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyKey = "some key"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logMethodLogic).Expects(dummySession, LogLevelWarn, "CircuitBreaker", dummyKey, "Circuit state changed from %v to %v",
+		circuitStateClosed, circuitStateOpen).Returns().Once()
+
+	// SUT + act
+	logCircuitStateChange(
+		dummySession,
+		dummyKey,
+		circuitStateClosed,
+		circuitStateOpen,
+	)
+}
+
+func TestLogCircuitStateChange_Other(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyKey = "some key"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logMethodLogic).Expects(dummySession, LogLevelInfo, "CircuitBreaker", dummyKey, "Circuit state changed from %v to %v",
+		circuitStateHalfOpen, circuitStateClosed).Returns().Once()
+
+	// SUT + act
+	logCircuitStateChange(
+		dummySession,
+		dummyKey,
+		circuitStateHalfOpen,
+		circuitStateClosed,
+	)
+}
+
+func TestAcquireCircuit_Closed(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{}
+
+	// SUT + act
+	var oldState, newState, allowed = acquireCircuit(
+		dummySetting,
+		dummyKey,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateClosed, oldState)
+	assert.Equal(t, circuitStateClosed, newState)
+	assert.True(t, allowed)
+}
+
+func TestAcquireCircuit_Open_WithinDuration(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+	var dummyOpenedAt = time.Now()
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {state: circuitStateOpen, openedAt: dummyOpenedAt},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyOpenedAt.Add(defaultCircuitOpenDuration - 1)).Once()
+
+	// SUT + act
+	var oldState, newState, allowed = acquireCircuit(
+		dummySetting,
+		dummyKey,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateOpen, oldState)
+	assert.Equal(t, circuitStateOpen, newState)
+	assert.False(t, allowed)
+}
+
+func TestAcquireCircuit_Open_DurationElapsed(t *testing.T) {
+	// arrange
+	var dummyOpenDuration = time.Duration(rand.IntN(100) + 1)
+	var dummySetting = &CircuitBreakerSetting{OpenDuration: dummyOpenDuration}
+	var dummyKey = "some key"
+	var dummyOpenedAt = time.Now()
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {state: circuitStateOpen, openedAt: dummyOpenedAt, successes: rand.IntN(100)},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyOpenedAt.Add(dummyOpenDuration)).Once()
+
+	// SUT + act
+	var oldState, newState, allowed = acquireCircuit(
+		dummySetting,
+		dummyKey,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateOpen, oldState)
+	assert.Equal(t, circuitStateHalfOpen, newState)
+	assert.True(t, allowed)
+	assert.Zero(t, circuitBreakers[dummyKey].successes)
+	assert.True(t, circuitBreakers[dummyKey].trialInFlight)
+}
+
+func TestAcquireCircuit_HalfOpen_TrialInFlight(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {state: circuitStateHalfOpen, trialInFlight: true},
+	}
+
+	// SUT + act
+	var oldState, newState, allowed = acquireCircuit(
+		dummySetting,
+		dummyKey,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateHalfOpen, oldState)
+	assert.Equal(t, circuitStateHalfOpen, newState)
+	assert.False(t, allowed)
+}
+
+func TestAllowCircuitRequest_NilSetting(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyHTTPRequest = &http.Request{}
+
+	// SUT + act
+	var err = allowCircuitRequest(
+		dummySession,
+		nil,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestAllowCircuitRequest_Allowed(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyKey = "some key"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getCircuitKey).Expects(dummySetting, dummyHTTPRequest).Returns(dummyKey).Once()
+	m.Mock(acquireCircuit).Expects(dummySetting, dummyKey).Returns(circuitStateOpen, circuitStateHalfOpen, true).Once()
+	m.Mock(logCircuitStateChange).Expects(dummySession, dummyKey, circuitStateOpen, circuitStateHalfOpen).Returns().Once()
+
+	// SUT + act
+	var err = allowCircuitRequest(
+		dummySession,
+		dummySetting,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestAllowCircuitRequest_Rejected(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyKey = "some key"
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getCircuitKey).Expects(dummySetting, dummyHTTPRequest).Returns(dummyKey).Once()
+	m.Mock(acquireCircuit).Expects(dummySetting, dummyKey).Returns(circuitStateOpen, circuitStateOpen, false).Once()
+	m.Mock(logCircuitStateChange).Expects(dummySession, dummyKey, circuitStateOpen, circuitStateOpen).Returns().Once()
+	m.Mock(newAppError).Expects(errorCodeCircuitBreak, fmt.Sprintf("The circuit for [%v] is %v", dummyKey, circuitStateOpen)).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = allowCircuitRequest(
+		dummySession,
+		dummySetting,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestIsCircuitFailure_ResponseError(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyResponseError = errors.New("some error")
+
+	// SUT + act
+	var result = isCircuitFailure(
+		dummySetting,
+		nil,
+		dummyResponseError,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestIsCircuitFailure_NilResponse(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+
+	// SUT + act
+	var result = isCircuitFailure(
+		dummySetting,
+		nil,
+		nil,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsCircuitFailure_DefaultStatusCodes(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+
+	// SUT + act
+	var result1 = isCircuitFailure(
+		dummySetting,
+		&http.Response{StatusCode: http.StatusServiceUnavailable},
+		nil,
+	)
+	var result2 = isCircuitFailure(
+		dummySetting,
+		&http.Response{StatusCode: http.StatusTooManyRequests},
+		nil,
+	)
+
+	// assert
+	assert.True(t, result1)
+	assert.False(t, result2)
+}
+
+func TestIsCircuitFailure_CustomStatusCodes(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{
+		FailureStatusCodes: []StatusCodeRange{
+			{Begin: http.StatusTooManyRequests, End: http.StatusTooManyRequests + 1},
+		},
+	}
+
+	// SUT + act
+	var result1 = isCircuitFailure(
+		dummySetting,
+		&http.Response{StatusCode: http.StatusServiceUnavailable},
+		nil,
+	)
+	var result2 = isCircuitFailure(
+		dummySetting,
+		&http.Response{StatusCode: http.StatusTooManyRequests},
+		nil,
+	)
+
+	// assert
+	assert.False(t, result1)
+	assert.True(t, result2)
+}
+
+func TestReleaseCircuit_Cancelled(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {state: circuitStateHalfOpen, trialInFlight: true},
+	}
+
+	// SUT + act
+	var oldState, newState = releaseCircuit(
+		dummySetting,
+		dummyKey,
+		true,
+		true,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateHalfOpen, oldState)
+	assert.Equal(t, circuitStateHalfOpen, newState)
+	assert.False(t, circuitBreakers[dummyKey].trialInFlight)
+}
+
+func TestReleaseCircuit_Failure_BelowThreshold(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {failures: defaultCircuitFailureThreshold - 2},
+	}
+
+	// SUT + act
+	var oldState, newState = releaseCircuit(
+		dummySetting,
+		dummyKey,
+		true,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateClosed, oldState)
+	assert.Equal(t, circuitStateClosed, newState)
+	assert.Equal(t, defaultCircuitFailureThreshold-1, circuitBreakers[dummyKey].failures)
+}
+
+func TestReleaseCircuit_Failure_ReachThreshold(t *testing.T) {
+	// arrange
+	var dummyFailureThreshold = rand.IntN(100) + 1
+	var dummySetting = &CircuitBreakerSetting{FailureThreshold: dummyFailureThreshold}
+	var dummyKey = "some key"
+	var dummyNow = time.Now()
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {failures: dummyFailureThreshold - 1},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyNow).Once()
+
+	// SUT + act
+	var oldState, newState = releaseCircuit(
+		dummySetting,
+		dummyKey,
+		true,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateClosed, oldState)
+	assert.Equal(t, circuitStateOpen, newState)
+	assert.Equal(t, dummyNow, circuitBreakers[dummyKey].openedAt)
+}
+
+func TestReleaseCircuit_Failure_HalfOpen(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+	var dummyNow = time.Now()
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {state: circuitStateHalfOpen, trialInFlight: true, successes: rand.IntN(100)},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyNow).Once()
+
+	// SUT + act
+	var oldState, newState = releaseCircuit(
+		dummySetting,
+		dummyKey,
+		true,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateHalfOpen, oldState)
+	assert.Equal(t, circuitStateOpen, newState)
+	assert.Zero(t, circuitBreakers[dummyKey].successes)
+	assert.False(t, circuitBreakers[dummyKey].trialInFlight)
+}
+
+func TestReleaseCircuit_Success_Closed(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {failures: rand.IntN(100)},
+	}
+
+	// SUT + act
+	var oldState, newState = releaseCircuit(
+		dummySetting,
+		dummyKey,
+		false,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateClosed, oldState)
+	assert.Equal(t, circuitStateClosed, newState)
+	assert.Zero(t, circuitBreakers[dummyKey].failures)
+}
+
+func TestReleaseCircuit_Success_HalfOpen_BelowThreshold(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{SuccessThreshold: 3}
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {state: circuitStateHalfOpen, trialInFlight: true, successes: 1},
+	}
+
+	// SUT + act
+	var oldState, newState = releaseCircuit(
+		dummySetting,
+		dummyKey,
+		false,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateHalfOpen, oldState)
+	assert.Equal(t, circuitStateHalfOpen, newState)
+	assert.Equal(t, 2, circuitBreakers[dummyKey].successes)
+}
+
+func TestReleaseCircuit_Success_HalfOpen_ReachThreshold(t *testing.T) {
+	// arrange
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyKey = "some key"
+
+	// stub
+	circuitBreakers = map[string]*circuitBreaker{
+		dummyKey: {state: circuitStateHalfOpen, trialInFlight: true},
+	}
+
+	// SUT + act
+	var oldState, newState = releaseCircuit(
+		dummySetting,
+		dummyKey,
+		false,
+		false,
+	)
+
+	// assert
+	assert.Equal(t, circuitStateHalfOpen, oldState)
+	assert.Equal(t, circuitStateClosed, newState)
+	assert.Zero(t, circuitBreakers[dummyKey].successes)
+}
+
+func TestRecordCircuitResult_NilSetting(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyHTTPRequest = &http.Request{}
+
+	// SUT + act
+	recordCircuitResult(
+		dummySession,
+		nil,
+		dummyHTTPRequest,
+		nil,
+		nil,
+	)
+}
+
+func TestRecordCircuitResult_HappyPath(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummySetting = &CircuitBreakerSetting{}
+	var dummyHTTPRequest = &http.Request{}
+	var dummyResponseObject = &http.Response{}
+	var dummyResponseError = fmt.Errorf("some error: %w", context.Canceled)
+	var dummyKey = "some key"
+	var dummyFailed = rand.IntN(100) > 50
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getCircuitKey).Expects(dummySetting, dummyHTTPRequest).Returns(dummyKey).Once()
+	m.Mock(isCircuitFailure).Expects(dummySetting, dummyResponseObject, dummyResponseError).Returns(dummyFailed).Once()
+	m.Mock(releaseCircuit).Expects(dummySetting, dummyKey, dummyFailed, true).Returns(circuitStateClosed, circuitStateOpen).Once()
+	m.Mock(logCircuitStateChange).Expects(dummySession, dummyKey, circuitStateClosed, circuitStateOpen).Returns().Once()
+
+	// SUT + act
+	recordCircuitResult(
+		dummySession,
+		dummySetting,
+		dummyHTTPRequest,
+		dummyResponseObject,
+		dummyResponseError,
+	)
+}
@@ -0,0 +1,58 @@
+package webserver
+
+import (
+	"crypto/tls"
+)
+
+// ClientAuthMode is the policy of requesting and verifying client certificates during TLS handshakes
+type ClientAuthMode string
+
+// These are the client auth modes; an empty mode is treated as ClientAuthRequire for compatibility
+const (
+	// ClientAuthNone neither requests nor accepts client certificates, e.g. for browsers and ordinary clients
+	ClientAuthNone ClientAuthMode = "none"
+	// ClientAuthRequest requests a client certificate without requiring or verifying it
+	ClientAuthRequest ClientAuthMode = "request"
+	// ClientAuthVerifyIfGiven requests a client certificate and verifies it against the CA cert pool if given, while still accepting clients without certificates
+	ClientAuthVerifyIfGiven ClientAuthMode = "verifyIfGiven"
+	// ClientAuthRequire requires a client certificate, verified against the CA cert pool if set
+	ClientAuthRequire ClientAuthMode = "require"
+)
+
+// getTLSClientAuth maps the client auth mode to the TLS client auth type; client certificates are only verified when a CA cert pool is available
+func getTLSClientAuth(
+	mode ClientAuthMode,
+	hasCaCertPool bool,
+) tls.ClientAuthType {
+	switch mode {
+	case ClientAuthNone:
+		return tls.NoClientCert
+	case ClientAuthRequest:
+		return tls.RequestClientCert
+	case ClientAuthVerifyIfGiven:
+		if hasCaCertPool {
+			return tls.VerifyClientCertIfGiven
+		}
+		return tls.RequestClientCert
+	default:
+		if hasCaCertPool {
+			return tls.RequireAndVerifyClientCert
+		}
+		return tls.RequireAnyClientCert
+	}
+}
+
+// verifyClientCertRequirement fails the session if its route requires a verified client certificate but none is given
+func verifyClientCertRequirement(
+	app *application,
+	session *session,
+) error {
+	if !app.clientCertRoutes[session.name] ||
+		session.GetClientCertificate() != nil {
+		return nil
+	}
+	return newAppError(
+		errorCodeUnauthorized,
+		errorMessageClientCertRequired,
+	)
+}
@@ -0,0 +1,117 @@
+package webserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestGetTLSClientAuth(t *testing.T) {
+	// arrange
+	var testCases = []struct {
+		mode          ClientAuthMode
+		hasCaCertPool bool
+		expected      tls.ClientAuthType
+	}{
+		{ClientAuthNone, false, tls.NoClientCert},
+		{ClientAuthNone, true, tls.NoClientCert},
+		{ClientAuthRequest, false, tls.RequestClientCert},
+		{ClientAuthRequest, true, tls.RequestClientCert},
+		{ClientAuthVerifyIfGiven, false, tls.RequestClientCert},
+		{ClientAuthVerifyIfGiven, true, tls.VerifyClientCertIfGiven},
+		{ClientAuthRequire, false, tls.RequireAnyClientCert},
+		{ClientAuthRequire, true, tls.RequireAndVerifyClientCert},
+		{"", false, tls.RequireAnyClientCert},
+		{"", true, tls.RequireAndVerifyClientCert},
+	}
+
+	for _, testCase := range testCases {
+		// SUT + act
+		var result = getTLSClientAuth(
+			testCase.mode,
+			testCase.hasCaCertPool,
+		)
+
+		// assert
+		assert.Equal(t, testCase.expected, result, "%v %v", testCase.mode, testCase.hasCaCertPool)
+	}
+}
+
+func TestVerifyClientCertRequirement_NotRequired(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		clientCertRoutes: map[string]bool{},
+	}
+	var dummySession = &session{
+		name:    "some name",
+		request: &http.Request{},
+	}
+
+	// SUT + act
+	var err = verifyClientCertRequirement(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestVerifyClientCertRequirement_Verified(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		clientCertRoutes: map[string]bool{"some name": true},
+	}
+	var dummySession = &session{
+		name: "some name",
+		request: &http.Request{
+			TLS: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{}}},
+			},
+		},
+	}
+
+	// SUT + act
+	var err = verifyClientCertRequirement(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestVerifyClientCertRequirement_NotVerified(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		clientCertRoutes: map[string]bool{"some name": true},
+	}
+	var dummySession = &session{
+		name: "some name",
+		request: &http.Request{
+			TLS: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{}},
+			},
+		},
+	}
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(errorCodeUnauthorized, errorMessageClientCertRequired).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = verifyClientCertRequirement(
+		dummyApplication,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
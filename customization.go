@@ -1,15 +1,19 @@
 package webserver
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"log/slog"
 	"net"
 	"net/http"
 	"runtime/debug"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Customization holds all customization methods
@@ -24,6 +28,8 @@ type Customization interface {
 	HandlerCustomization
 	// WebRequestCustomization holds customization methods related to web requests
 	WebRequestCustomization
+	// TelemetryCustomization holds customization methods related to telemetry
+	TelemetryCustomization
 }
 
 // BootstrapCustomization holds customization methods related to bootstrapping
@@ -42,6 +48,21 @@ type BootstrapCustomization interface {
 type LoggingCustomization interface {
 	// Log is to customize the logging backend for the whole application
 	Log(session Session, logType LogType, logLevel LogLevel, category, subcategory, description string)
+
+	// LogHandler is to customize the structured logging backend as a log/slog handler, e.g. slog.NewJSONHandler, receiving typed fields such as session ID, route, method, status and duration; if not set or nil, Log is used instead
+	LogHandler() slog.Handler
+
+	// LogFilterHeader is to customize the name of the request header overriding the runtime log filter for that request, in the form of "<LogType>;<LogLevel>", e.g. "FullDebugging;Debug"; if empty, no request could override the runtime log filter
+	LogFilterHeader() string
+
+	// LogRedaction is to customize the redaction of sensitive data, such as headers, JSON fields or patterns, in EndpointRequest, EndpointResponse, WebcallRequest and WebcallResponse logs before they reach the logging backend; if not set or nil, no redaction is applied
+	LogRedaction() *RedactionSetting
+
+	// LogTruncationLimit is to customize the maximum length in bytes of descriptions of the given log type, beyond which descriptions are truncated and marked; if 0 or negative, no truncation is applied
+	LogTruncationLimit(logType LogType) int
+
+	// LogBodySampleRate is to customize the sampling rate, from 0 to 1, of sessions having their request and response bodies logged, e.g. 0.01 for 1% of sessions per route; bodies of sessions not sampled are logged by sizes only
+	LogBodySampleRate(session Session) float64
 }
 
 // HostingCustomization holds customization methods related to hosting
@@ -52,9 +73,30 @@ type HostingCustomization interface {
 	// CaCertPool is to customize the CA cert pool for incoming client certificate validation; if not set or nil, no validation is conducted for incoming client certificates
 	CaCertPool() *x509.CertPool
 
+	// CertificateProvider is to customize the provider of the server certificate and CA cert pool, looked up on each TLS handshake so that rotated certificates take effect without restarting; if set, ServerCert and CaCertPool will have no effect
+	CertificateProvider() CertificateProvider
+
+	// ClientAuthMode is to customize the policy of requesting and verifying client certificates when hosting with HTTPS; if not set or empty, client certificates are required, and verified if CaCertPool is set
+	ClientAuthMode() ClientAuthMode
+
 	// GraceShutdownWaitTime is to customize the graceful shutdown wait time for the application
 	GraceShutdownWaitTime() time.Duration
 
+	// ShutdownTimeout is to customize the timeout of the given shutdown phase; if 0 or negative, GraceShutdownWaitTime is used for the Drain phase and 30 seconds for other phases
+	ShutdownTimeout(phase ShutdownPhase) time.Duration
+
+	// ShutdownHook is to customize the logic run during the given shutdown phase, within the timeout of that phase; the Drain hook runs concurrently with the server waiting for in-flight requests
+	ShutdownHook(ctx context.Context, phase ShutdownPhase) error
+
+	// ShutdownDrainDelay is to customize the delay between receiving the shutdown signal, when readiness starts failing, and shutting down the server, so that load balancers could stop sending new requests in the meantime
+	ShutdownDrainDelay() time.Duration
+
+	// HealthChecks is to customize the checks reported by the built-in liveness endpoint /healthz, which responds 503 Service Unavailable if any check fails
+	HealthChecks() []HealthCheck
+
+	// ReadinessChecks is to customize the checks reported by the built-in readiness endpoint /readyz, which responds 503 Service Unavailable if any check fails or once the shutdown signal is received
+	ReadinessChecks() []HealthCheck
+
 	// Routes is to customize the routes registration
 	Routes() []Route
 
@@ -72,6 +114,12 @@ type HostingCustomization interface {
 
 	// Listener is to customize the override of net.Listener to be used by http.Serve and http.ServeTLS; usually useful with UDS connections for local web server
 	Listener() net.Listener
+
+	// Listeners is to customize the additional listeners serving the same routes along with the application address, each with its own TLS settings; all listeners share the same lifecycle of the application
+	Listeners() []ListenerSetting
+
+	// AdminServer is to customize the optional admin server hosting the health, metrics, routes listing, log filter and pprof endpoints on its own address, sharing the same lifecycle of the application; if set, the health and metrics endpoints are no longer hosted by the listeners serving the routes
+	AdminServer() *AdminSetting
 }
 
 // HandlerCustomization holds customization methods related to handlers
@@ -88,6 +136,21 @@ type HandlerCustomization interface {
 	// InterpretError is to customize how application interpret an error into HTTP status code and corresponding response body
 	InterpretError(err error) (int, string)
 
+	// RequestDecoders is to customize the decoders picked by the Content-Type header of incoming requests for GetRequestBody into struct or map data templates; when none matches, or for any other data template, the request body is unmarshalled as JSON or primitive types
+	RequestDecoders() []RequestDecoder
+
+	// ValidateRequest is to customize the validation of request body, parameters, queries and headers after they are unmarshalled by session; all returned violations are combined into one bad request error
+	ValidateRequest(session Session, dataTemplate any) []error
+
+	// ResponseEncoders is to customize the encoders negotiated against the Accept header of incoming requests, JSON only by default; when the JSON encoder is negotiated, or none matches, InterpretSuccess and InterpretError are used, otherwise InterpretSuccessContent and InterpretErrorContent are used with the negotiated encoder
+	ResponseEncoders() []ResponseEncoder
+
+	// InterpretSuccessContent is to customize how application interpret a response content into HTTP status code, content type and raw response body with the negotiated encoder
+	InterpretSuccessContent(responseContent any, encoder ResponseEncoder) (int, string, []byte)
+
+	// InterpretErrorContent is to customize how application interpret an error into HTTP status code, content type and raw response body with the negotiated encoder
+	InterpretErrorContent(err error, encoder ResponseEncoder) (int, string, []byte)
+
 	// RecoverPanic is to customize the recovery of panic into a valid response and error in case it happens (for recoverable panic only)
 	RecoverPanic(session Session, recoverResult any) (any, error)
 
@@ -120,6 +183,21 @@ type WebRequestCustomization interface {
 
 	// WrapResponse is to customize the post-processing of any webcall communications through HTTP/HTTPS by session; utilize this method if needed for additional response or error handling, etc.
 	WrapResponse(session Session, httpResponse *http.Response, httpError error) (*http.Response, error)
+
+	// CircuitBreaker is to customize the circuit breaker setting applied to the given webcall, e.g. thresholds per host or route; if not set or nil, no circuit breaker is applied
+	CircuitBreaker(session Session, httpRequest *http.Request) *CircuitBreakerSetting
+}
+
+// TelemetryCustomization holds customization methods related to telemetry
+type TelemetryCustomization interface {
+	// TracerProvider is to customize the OpenTelemetry tracer provider creating a server span per session named after its route pattern and a client span per webcall; if not set or nil, no span is created
+	TracerProvider() trace.TracerProvider
+
+	// MeterProvider is to customize the OpenTelemetry meter provider recording the durations of sessions and webcalls; if not set or nil, no metric is recorded
+	MeterProvider() metric.MeterProvider
+
+	// MetricsPath is to customize the path of the Prometheus metrics endpoint exposing request, webcall, panic and AppError metrics in text exposition format, e.g. "/metrics"; if empty, no metrics endpoint is registered
+	MetricsPath() string
 }
 
 var (
@@ -159,6 +237,31 @@ func (customization *DefaultCustomization) Log(session Session, logType LogType,
 	)
 }
 
+// LogHandler is to customize the structured logging backend as a log/slog handler, e.g. slog.NewJSONHandler, receiving typed fields such as session ID, route, method, status and duration; if not set or nil, Log is used instead
+func (customization *DefaultCustomization) LogHandler() slog.Handler {
+	return nil
+}
+
+// LogFilterHeader is to customize the name of the request header overriding the runtime log filter for that request, in the form of "<LogType>;<LogLevel>", e.g. "FullDebugging;Debug"; if empty, no request could override the runtime log filter
+func (customization *DefaultCustomization) LogFilterHeader() string {
+	return ""
+}
+
+// LogRedaction is to customize the redaction of sensitive data, such as headers, JSON fields or patterns, in EndpointRequest, EndpointResponse, WebcallRequest and WebcallResponse logs before they reach the logging backend; if not set or nil, no redaction is applied
+func (customization *DefaultCustomization) LogRedaction() *RedactionSetting {
+	return nil
+}
+
+// LogTruncationLimit is to customize the maximum length in bytes of descriptions of the given log type, beyond which descriptions are truncated and marked; if 0 or negative, no truncation is applied
+func (customization *DefaultCustomization) LogTruncationLimit(logType LogType) int {
+	return 0
+}
+
+// LogBodySampleRate is to customize the sampling rate, from 0 to 1, of sessions having their request and response bodies logged, e.g. 0.01 for 1% of sessions per route; bodies of sessions not sampled are logged by sizes only
+func (customization *DefaultCustomization) LogBodySampleRate(session Session) float64 {
+	return 1
+}
+
 // ServerCert is to customize the server certificate for application; also determines the server hosting security option (HTTP v.s. HTTPS)
 func (customization *DefaultCustomization) ServerCert() *tls.Certificate {
 	return nil
@@ -169,11 +272,46 @@ func (customization *DefaultCustomization) CaCertPool() *x509.CertPool {
 	return nil
 }
 
+// CertificateProvider is to customize the provider of the server certificate and CA cert pool, looked up on each TLS handshake so that rotated certificates take effect without restarting; if set, ServerCert and CaCertPool will have no effect
+func (customization *DefaultCustomization) CertificateProvider() CertificateProvider {
+	return nil
+}
+
+// ClientAuthMode is to customize the policy of requesting and verifying client certificates when hosting with HTTPS; if not set or empty, client certificates are required, and verified if CaCertPool is set
+func (customization *DefaultCustomization) ClientAuthMode() ClientAuthMode {
+	return ""
+}
+
 // GraceShutdownWaitTime is to customize the graceful shutdown wait time for the application
 func (customization *DefaultCustomization) GraceShutdownWaitTime() time.Duration {
 	return 3 * time.Minute
 }
 
+// ShutdownTimeout is to customize the timeout of the given shutdown phase; if 0 or negative, GraceShutdownWaitTime is used for the Drain phase and 30 seconds for other phases
+func (customization *DefaultCustomization) ShutdownTimeout(phase ShutdownPhase) time.Duration {
+	return 0
+}
+
+// ShutdownHook is to customize the logic run during the given shutdown phase, within the timeout of that phase; the Drain hook runs concurrently with the server waiting for in-flight requests
+func (customization *DefaultCustomization) ShutdownHook(ctx context.Context, phase ShutdownPhase) error {
+	return nil
+}
+
+// ShutdownDrainDelay is to customize the delay between receiving the shutdown signal, when readiness starts failing, and shutting down the server, so that load balancers could stop sending new requests in the meantime
+func (customization *DefaultCustomization) ShutdownDrainDelay() time.Duration {
+	return 0
+}
+
+// HealthChecks is to customize the checks reported by the built-in liveness endpoint /healthz, which responds 503 Service Unavailable if any check fails
+func (customization *DefaultCustomization) HealthChecks() []HealthCheck {
+	return nil
+}
+
+// ReadinessChecks is to customize the checks reported by the built-in readiness endpoint /readyz, which responds 503 Service Unavailable if any check fails or once the shutdown signal is received
+func (customization *DefaultCustomization) ReadinessChecks() []HealthCheck {
+	return nil
+}
+
 // Routes is to customize the routes registration
 func (customization *DefaultCustomization) Routes() []Route {
 	return []Route{}
@@ -204,6 +342,16 @@ func (customization *DefaultCustomization) Listener() net.Listener {
 	return nil
 }
 
+// Listeners is to customize the additional listeners serving the same routes along with the application address, each with its own TLS settings; all listeners share the same lifecycle of the application
+func (customization *DefaultCustomization) Listeners() []ListenerSetting {
+	return nil
+}
+
+// AdminServer is to customize the optional admin server hosting the health, metrics, routes listing, log filter and pprof endpoints on its own address, sharing the same lifecycle of the application; if set, the health and metrics endpoints are no longer hosted by the listeners serving the routes
+func (customization *DefaultCustomization) AdminServer() *AdminSetting {
+	return nil
+}
+
 // PreAction is to customize the pre-action used before each route action takes place, e.g. authorization, etc.
 func (customization *DefaultCustomization) PreAction(session Session) error {
 	return nil
@@ -237,6 +385,60 @@ func (customization *DefaultCustomization) InterpretError(err error) (int, strin
 		typedError.HTTPResponseMessage()
 }
 
+// RequestDecoders is to customize the decoders picked by the Content-Type header of incoming requests for GetRequestBody into struct or map data templates; when none matches, or for any other data template, the request body is unmarshalled as JSON or primitive types
+func (customization *DefaultCustomization) RequestDecoders() []RequestDecoder {
+	return []RequestDecoder{
+		&FormRequestDecoder{},
+		&MultipartRequestDecoder{},
+		&XMLRequestDecoder{},
+	}
+}
+
+// ValidateRequest is to customize the validation of request body, parameters, queries and headers after they are unmarshalled by session; all returned violations are combined into one bad request error
+func (customization *DefaultCustomization) ValidateRequest(session Session, dataTemplate any) []error {
+	return ValidateStruct(
+		dataTemplate,
+	)
+}
+
+// ResponseEncoders is to customize the encoders negotiated against the Accept header of incoming requests, JSON only by default; when the JSON encoder is negotiated, or none matches, InterpretSuccess and InterpretError are used, otherwise InterpretSuccessContent and InterpretErrorContent are used with the negotiated encoder
+func (customization *DefaultCustomization) ResponseEncoders() []ResponseEncoder {
+	return []ResponseEncoder{
+		&JSONResponseEncoder{},
+	}
+}
+
+// InterpretSuccessContent is to customize how application interpret a response content into HTTP status code, content type and raw response body with the negotiated encoder
+func (customization *DefaultCustomization) InterpretSuccessContent(responseContent any, encoder ResponseEncoder) (int, string, []byte) {
+	if isInterfaceValueNil(responseContent) {
+		return http.StatusNoContent, "", nil
+	}
+	var responseBody, encodeError = encoder.Encode(responseContent)
+	if encodeError != nil {
+		return http.StatusInternalServerError, ContentTypeText, []byte(encodeError.Error())
+	}
+	if len(responseBody) == 0 {
+		return http.StatusNoContent, "", nil
+	}
+	return http.StatusOK, encoder.ContentType(), responseBody
+}
+
+// InterpretErrorContent is to customize how application interpret an error into HTTP status code, content type and raw response body with the negotiated encoder
+func (customization *DefaultCustomization) InterpretErrorContent(err error, encoder ResponseEncoder) (int, string, []byte) {
+	var statusCode = http.StatusInternalServerError
+	var responseContent any = err.Error()
+	var typedError, isTyped = err.(AppHTTPError)
+	if isTyped {
+		statusCode = typedError.HTTPStatusCode()
+		responseContent = typedError
+	}
+	var responseBody, encodeError = encoder.Encode(responseContent)
+	if encodeError != nil {
+		return statusCode, ContentTypeText, []byte(err.Error())
+	}
+	return statusCode, encoder.ContentType(), responseBody
+}
+
 func getRecoverError(recoverResult any) error {
 	var err, ok = recoverResult.(error)
 	if !ok {
@@ -307,3 +509,23 @@ func (customization *DefaultCustomization) WrapRequest(session Session, httpRequ
 func (customization *DefaultCustomization) WrapResponse(session Session, httpResponse *http.Response, httpError error) (*http.Response, error) {
 	return httpResponse, httpError
 }
+
+// CircuitBreaker is to customize the circuit breaker setting applied to the given webcall, e.g. thresholds per host or route; if not set or nil, no circuit breaker is applied
+func (customization *DefaultCustomization) CircuitBreaker(session Session, httpRequest *http.Request) *CircuitBreakerSetting {
+	return nil
+}
+
+// TracerProvider is to customize the OpenTelemetry tracer provider creating a server span per session named after its route pattern and a client span per webcall; if not set or nil, no span is created
+func (customization *DefaultCustomization) TracerProvider() trace.TracerProvider {
+	return nil
+}
+
+// MeterProvider is to customize the OpenTelemetry meter provider recording the durations of sessions and webcalls; if not set or nil, no metric is recorded
+func (customization *DefaultCustomization) MeterProvider() metric.MeterProvider {
+	return nil
+}
+
+// MetricsPath is to customize the path of the Prometheus metrics endpoint exposing request, webcall, panic and AppError metrics in text exposition format, e.g. "/metrics"; if empty, no metrics endpoint is registered
+func (customization *DefaultCustomization) MetricsPath() string {
+	return ""
+}
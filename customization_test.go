@@ -1,6 +1,7 @@
 package webserver
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand/v2"
@@ -77,6 +78,50 @@ func TestDefaultCustomization_Log_HappyPath(t *testing.T) {
 	)
 }
 
+func TestDefaultCustomization_LogHandler(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.LogHandler()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_LogFilterHeader(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.LogFilterHeader()
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestDefaultCustomization_LogRedaction(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.LogRedaction()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_LogTruncationLimit(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.LogTruncationLimit(
+		LogTypeEndpointResponse,
+	)
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_LogBodySampleRate(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.LogBodySampleRate(
+		&session{},
+	)
+
+	// assert
+	assert.Equal(t, 1.0, result)
+}
+
 func TestDefaultCustomization_ServerCert(t *testing.T) {
 	// SUT + act
 	var result = customizationDefault.ServerCert()
@@ -93,6 +138,22 @@ func TestDefaultCustomization_CaCertPool(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestDefaultCustomization_CertificateProvider(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.CertificateProvider()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_ClientAuthMode(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.ClientAuthMode()
+
+	// assert
+	assert.Empty(t, result)
+}
+
 func TestDefaultCustomization_GraceShutdownWaitTime(t *testing.T) {
 	// SUT + act
 	var result = customizationDefault.GraceShutdownWaitTime()
@@ -101,6 +162,46 @@ func TestDefaultCustomization_GraceShutdownWaitTime(t *testing.T) {
 	assert.Equal(t, 3*time.Minute, result)
 }
 
+func TestDefaultCustomization_ShutdownTimeout(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.ShutdownTimeout(ShutdownPhaseDrain)
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_ShutdownHook(t *testing.T) {
+	// SUT + act
+	var err = customizationDefault.ShutdownHook(context.Background(), ShutdownPhasePreDrain)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestDefaultCustomization_ShutdownDrainDelay(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.ShutdownDrainDelay()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestDefaultCustomization_HealthChecks(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.HealthChecks()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_ReadinessChecks(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.ReadinessChecks()
+
+	// assert
+	assert.Nil(t, result)
+}
+
 func TestDefaultCustomization_Routes(t *testing.T) {
 	// SUT + act
 	var results = customizationDefault.Routes()
@@ -161,6 +262,22 @@ func TestDefaultCustomization_Listener(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestDefaultCustomization_Listeners(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.Listeners()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_AdminServer(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.AdminServer()
+
+	// assert
+	assert.Nil(t, result)
+}
+
 func TestDefaultCustomization_PreAction(t *testing.T) {
 	// arrange
 	var dummySession Session
@@ -288,6 +405,209 @@ func TestDefaultCustomization_InterpretError_AppError(t *testing.T) {
 	assert.Equal(t, dummyResponseMessage, message)
 }
 
+func TestDefaultCustomization_RequestDecoders(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.RequestDecoders()
+
+	// assert
+	assert.Equal(t, []RequestDecoder{&FormRequestDecoder{}, &MultipartRequestDecoder{}, &XMLRequestDecoder{}}, result)
+}
+
+func TestDefaultCustomization_ValidateRequest(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyDataTemplate = rand.Int()
+	var dummyViolations = []error{
+		errors.New("some violation 1"),
+		errors.New("some violation 2"),
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(ValidateStruct).Expects(dummyDataTemplate).Returns(dummyViolations).Once()
+
+	// SUT + act
+	var result = customizationDefault.ValidateRequest(
+		dummySession,
+		dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyViolations, result)
+}
+
+func TestDefaultCustomization_ResponseEncoders(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.ResponseEncoders()
+
+	// assert
+	assert.Equal(t, []ResponseEncoder{&JSONResponseEncoder{}}, result)
+}
+
+func TestDefaultCustomization_InterpretSuccessContent_NilResponseContent(t *testing.T) {
+	// arrange
+	var dummyResponseContent any
+	var dummyEncoder = &XMLResponseEncoder{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isInterfaceValueNil).Expects(dummyResponseContent).Returns(true).Once()
+
+	// SUT + act
+	var code, contentType, body = customizationDefault.InterpretSuccessContent(
+		dummyResponseContent,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Zero(t, contentType)
+	assert.Nil(t, body)
+}
+
+func TestDefaultCustomization_InterpretSuccessContent_EncodeError(t *testing.T) {
+	// arrange
+	var dummyResponseContent = rand.Int()
+	var dummyEncoder = &XMLResponseEncoder{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isInterfaceValueNil).Expects(dummyResponseContent).Returns(false).Once()
+	m.Mock((*XMLResponseEncoder).Encode).Expects(dummyEncoder, dummyResponseContent).Returns([]byte("some body"), errors.New("some error")).Once()
+
+	// SUT + act
+	var code, contentType, body = customizationDefault.InterpretSuccessContent(
+		dummyResponseContent,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, ContentTypeText, contentType)
+	assert.Equal(t, []byte("some error"), body)
+}
+
+func TestDefaultCustomization_InterpretSuccessContent_EmptyBody(t *testing.T) {
+	// arrange
+	var dummyResponseContent = rand.Int()
+	var dummyEncoder = &XMLResponseEncoder{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isInterfaceValueNil).Expects(dummyResponseContent).Returns(false).Once()
+	m.Mock((*XMLResponseEncoder).Encode).Expects(dummyEncoder, dummyResponseContent).Returns([]byte{}, nil).Once()
+
+	// SUT + act
+	var code, contentType, body = customizationDefault.InterpretSuccessContent(
+		dummyResponseContent,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Zero(t, contentType)
+	assert.Nil(t, body)
+}
+
+func TestDefaultCustomization_InterpretSuccessContent_HappyPath(t *testing.T) {
+	// arrange
+	var dummyResponseContent = rand.Int()
+	var dummyEncoder = &XMLResponseEncoder{}
+	var dummyBody = []byte("some body")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isInterfaceValueNil).Expects(dummyResponseContent).Returns(false).Once()
+	m.Mock((*XMLResponseEncoder).Encode).Expects(dummyEncoder, dummyResponseContent).Returns(dummyBody, nil).Once()
+
+	// SUT + act
+	var code, contentType, body = customizationDefault.InterpretSuccessContent(
+		dummyResponseContent,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, ContentTypeXML, contentType)
+	assert.Equal(t, dummyBody, body)
+}
+
+func TestDefaultCustomization_InterpretErrorContent_NormalError(t *testing.T) {
+	// arrange
+	var dummyErrorMessage = "some error message"
+	var dummyError = errors.New(dummyErrorMessage)
+	var dummyEncoder = &XMLResponseEncoder{}
+	var dummyBody = []byte("some body")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*XMLResponseEncoder).Encode).Expects(dummyEncoder, dummyErrorMessage).Returns(dummyBody, nil).Once()
+
+	// SUT + act
+	var code, contentType, body = customizationDefault.InterpretErrorContent(
+		dummyError,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, ContentTypeXML, contentType)
+	assert.Equal(t, dummyBody, body)
+}
+
+func TestDefaultCustomization_InterpretErrorContent_AppError(t *testing.T) {
+	// arrange
+	var dummyAppError = &appError{Code: errorCodeBadRequest, Message: "some error message"}
+	var dummyEncoder = &XMLResponseEncoder{}
+
+	// SUT + act
+	var code, contentType, body = customizationDefault.InterpretErrorContent(
+		dummyAppError,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, ContentTypeXML, contentType)
+	assert.Equal(t, "<appError><code>BadRequest</code><message>some error message</message></appError>", string(body))
+}
+
+func TestDefaultCustomization_InterpretErrorContent_EncodeError(t *testing.T) {
+	// arrange
+	var dummyErrorMessage = "some error message"
+	var dummyError = errors.New(dummyErrorMessage)
+	var dummyEncoder = &XMLResponseEncoder{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*XMLResponseEncoder).Encode).Expects(dummyEncoder, dummyErrorMessage).Returns(nil, errors.New("some error")).Once()
+
+	// SUT + act
+	var code, contentType, body = customizationDefault.InterpretErrorContent(
+		dummyError,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Equal(t, ContentTypeText, contentType)
+	assert.Equal(t, []byte(dummyErrorMessage), body)
+}
+
 func TestGetRecoverError_Error(t *testing.T) {
 	// arrange
 	var dummyRecoverResult = errors.New("some error")
@@ -436,3 +756,39 @@ func TestDefaultCustomization_WrapResponse(t *testing.T) {
 	assert.Equal(t, dummyResponse, result)
 	assert.Equal(t, dummyError, err)
 }
+
+func TestDefaultCustomization_CircuitBreaker(t *testing.T) {
+	// arrange
+	var dummySession Session
+	var dummyRequest = &http.Request{Host: "some host"}
+
+	// SUT + act
+	var result = customizationDefault.CircuitBreaker(dummySession, dummyRequest)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_TracerProvider(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.TracerProvider()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_MeterProvider(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.MeterProvider()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestDefaultCustomization_MetricsPath(t *testing.T) {
+	// SUT + act
+	var result = customizationDefault.MetricsPath()
+
+	// assert
+	assert.Empty(t, result)
+}
@@ -0,0 +1,322 @@
+package webserver
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// defaultMultipartMemory is the maximum bytes of multipart file parts kept in memory, beyond which the parts are stored in temporary files, aligned with net/http
+const defaultMultipartMemory = 32 << 20
+
+var (
+	typeOfFileHeader  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	typeOfFileHeaders = reflect.TypeOf(([]*multipart.FileHeader)(nil))
+	typeOfBytes       = reflect.TypeOf(([]byte)(nil))
+)
+
+// sessionRequestDecoder is implemented by built-in request decoders holding resources to be released once the session finishes, e.g. temporary files of multipart forms
+type sessionRequestDecoder interface {
+	decodeForSession(session *session, contentType string, body string, dataTemplate any) error
+}
+
+// RequestDecoder decodes request bodies of a specific content type into data templates, picked by the Content-Type header of the incoming request
+type RequestDecoder interface {
+	// ContentType returns the media type of the request bodies this decoder handles, matched against the Content-Type header of the incoming request
+	ContentType() string
+	// Decode decodes the given request body into the data template, with the full Content-Type header value given for parameters such as boundary or charset
+	Decode(contentType string, body string, dataTemplate any) error
+}
+
+// FormRequestDecoder is the built-in RequestDecoder for application/x-www-form-urlencoded, decoding into *url.Values or structs with fields named by "form" tags
+type FormRequestDecoder struct{}
+
+// ContentType returns the media type of the request bodies this decoder handles, matched against the Content-Type header of the incoming request
+func (decoder *FormRequestDecoder) ContentType() string {
+	return ContentTypeForm
+}
+
+// Decode decodes the given request body into the data template, with the full Content-Type header value given for parameters such as boundary or charset
+func (decoder *FormRequestDecoder) Decode(contentType string, body string, dataTemplate any) error {
+	var values, parseError = url.ParseQuery(
+		body,
+	)
+	if parseError != nil {
+		return parseError
+	}
+	return decodeForm(
+		values,
+		nil,
+		dataTemplate,
+	)
+}
+
+// MultipartRequestDecoder is the built-in RequestDecoder for multipart/form-data, decoding into *multipart.Form, *url.Values or structs with fields named by "form" tags, where file parts go to fields of *multipart.FileHeader or []*multipart.FileHeader; file parts beyond 32 MB are stored in temporary files, which are removed once the request finishes when decoded through session, so the decoded form and file headers must not be used after the action returns
+type MultipartRequestDecoder struct{}
+
+// ContentType returns the media type of the request bodies this decoder handles, matched against the Content-Type header of the incoming request
+func (decoder *MultipartRequestDecoder) ContentType() string {
+	return ContentTypeMultipart
+}
+
+func (decoder *MultipartRequestDecoder) readForm(contentType string, body string) (*multipart.Form, error) {
+	var _, parameters, parseError = mime.ParseMediaType(
+		contentType,
+	)
+	if parseError != nil {
+		return nil, parseError
+	}
+	var boundary = parameters["boundary"]
+	if boundary == "" {
+		return nil, http.ErrMissingBoundary
+	}
+	return multipart.NewReader(
+		strings.NewReader(body),
+		boundary,
+	).ReadForm(
+		defaultMultipartMemory,
+	)
+}
+
+func decodeMultipartForm(form *multipart.Form, dataTemplate any) error {
+	var formTemplate, isForm = dataTemplate.(*multipart.Form)
+	if isForm {
+		*formTemplate = *form
+		return nil
+	}
+	return decodeForm(
+		form.Value,
+		form.File,
+		dataTemplate,
+	)
+}
+
+// Decode decodes the given request body into the data template, with the full Content-Type header value given for parameters such as boundary or charset; when called directly instead of through session, the caller owns the temporary files of the form and should decode into *multipart.Form to remove them by RemoveAll once done
+func (decoder *MultipartRequestDecoder) Decode(contentType string, body string, dataTemplate any) error {
+	var form, formError = decoder.readForm(
+		contentType,
+		body,
+	)
+	if formError != nil {
+		return formError
+	}
+	return decodeMultipartForm(
+		form,
+		dataTemplate,
+	)
+}
+
+func (decoder *MultipartRequestDecoder) decodeForSession(session *session, contentType string, body string, dataTemplate any) error {
+	var form, formError = decoder.readForm(
+		contentType,
+		body,
+	)
+	if formError != nil {
+		return formError
+	}
+	session.addCleanup(
+		func() {
+			form.RemoveAll()
+		},
+	)
+	return decodeMultipartForm(
+		form,
+		dataTemplate,
+	)
+}
+
+// XMLRequestDecoder is the built-in RequestDecoder for application/xml
+type XMLRequestDecoder struct{}
+
+// ContentType returns the media type of the request bodies this decoder handles, matched against the Content-Type header of the incoming request
+func (decoder *XMLRequestDecoder) ContentType() string {
+	return ContentTypeXML
+}
+
+// Decode decodes the given request body into the data template, with the full Content-Type header value given for parameters such as boundary or charset
+func (decoder *XMLRequestDecoder) Decode(contentType string, body string, dataTemplate any) error {
+	return xml.Unmarshal(
+		[]byte(body),
+		dataTemplate,
+	)
+}
+
+func getFormFieldName(field reflect.StructField) string {
+	var name, _, _ = strings.Cut(
+		field.Tag.Get("form"),
+		",",
+	)
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+func decodeFormField(fieldValue reflect.Value, values []string, fileHeaders []*multipart.FileHeader) error {
+	switch fieldValue.Type() {
+	case typeOfFileHeader:
+		if len(fileHeaders) > 0 {
+			fieldValue.Set(
+				reflect.ValueOf(fileHeaders[0]),
+			)
+		}
+		return nil
+	case typeOfFileHeaders:
+		fieldValue.Set(
+			reflect.ValueOf(fileHeaders),
+		)
+		return nil
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	if fieldValue.Type() == typeOfBytes {
+		fieldValue.SetBytes(
+			[]byte(values[0]),
+		)
+		return nil
+	}
+	if fieldValue.Kind() != reflect.Slice {
+		return tryUnmarshal(
+			values[0],
+			fieldValue.Addr().Interface(),
+		)
+	}
+	var sliceValue = reflect.MakeSlice(
+		fieldValue.Type(),
+		len(values),
+		len(values),
+	)
+	for index, value := range values {
+		var unmarshalError = tryUnmarshal(
+			value,
+			sliceValue.Index(index).Addr().Interface(),
+		)
+		if unmarshalError != nil {
+			return unmarshalError
+		}
+	}
+	fieldValue.Set(sliceValue)
+	return nil
+}
+
+// decodeForm decodes the given form values and files into the data template, being either *url.Values, *map[string][]string, or a pointer to struct with fields named by "form" tags
+func decodeForm(values url.Values, fileHeaders map[string][]*multipart.FileHeader, dataTemplate any) error {
+	switch typedTemplate := dataTemplate.(type) {
+	case *url.Values:
+		*typedTemplate = values
+		return nil
+	case *map[string][]string:
+		*typedTemplate = values
+		return nil
+	}
+	var templateValue = reflect.ValueOf(dataTemplate)
+	if templateValue.Kind() != reflect.Pointer ||
+		templateValue.IsNil() ||
+		templateValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(
+			"unable to decode form into data template of type %T",
+			dataTemplate,
+		)
+	}
+	var structValue = templateValue.Elem()
+	var decodeErrors []error
+	for index := 0; index < structValue.NumField(); index++ {
+		var field = structValue.Type().Field(index)
+		var name = getFormFieldName(field)
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		var fieldError = decodeFormField(
+			structValue.Field(index),
+			values[name],
+			fileHeaders[name],
+		)
+		if fieldError != nil {
+			decodeErrors = append(
+				decodeErrors,
+				fmt.Errorf(
+					"unable to decode form field [%v]: %w",
+					name,
+					fieldError,
+				),
+			)
+		}
+	}
+	return errors.Join(decodeErrors...)
+}
+
+// getRequestDecoder returns the registered decoder matching the media type of the given Content-Type header value; returns nil if none matches
+func getRequestDecoder(session *session, contentType string) RequestDecoder {
+	var mediaType, _, parseError = mime.ParseMediaType(
+		contentType,
+	)
+	if parseError != nil {
+		return nil
+	}
+	for _, decoder := range session.customization.RequestDecoders() {
+		if isInterfaceValueNil(decoder) {
+			continue
+		}
+		var decoderType, _, _ = mime.ParseMediaType(
+			decoder.ContentType(),
+		)
+		if decoderType == mediaType {
+			return decoder
+		}
+	}
+	return nil
+}
+
+// isStructuredTemplate checks whether the data template points to a struct or a map, e.g. url.Values, which are the only kinds of templates handed to request decoders
+func isStructuredTemplate(dataTemplate any) bool {
+	var templateType = reflect.TypeOf(dataTemplate)
+	if templateType == nil ||
+		templateType.Kind() != reflect.Pointer {
+		return false
+	}
+	var templateKind = templateType.Elem().Kind()
+	return templateKind == reflect.Struct ||
+		templateKind == reflect.Map
+}
+
+// decodeRequestBody decodes the request body with the decoder picked by the Content-Type header of the request; falls back to JSON and primitive types when no decoder matches or the data template is neither a struct nor a map, e.g. *string or *[]byte
+func decodeRequestBody(session *session, httpRequest *http.Request, requestBody string, dataTemplate any) error {
+	if !isStructuredTemplate(dataTemplate) {
+		return tryUnmarshal(
+			requestBody,
+			dataTemplate,
+		)
+	}
+	var contentType = httpRequest.Header.Get("Content-Type")
+	var decoder = getRequestDecoder(
+		session,
+		contentType,
+	)
+	if decoder == nil {
+		return tryUnmarshal(
+			requestBody,
+			dataTemplate,
+		)
+	}
+	var sessionDecoder, isSessionDecoder = decoder.(sessionRequestDecoder)
+	if isSessionDecoder {
+		return sessionDecoder.decodeForSession(
+			session,
+			contentType,
+			requestBody,
+			dataTemplate,
+		)
+	}
+	return decoder.Decode(
+		contentType,
+		requestBody,
+		dataTemplate,
+	)
+}
@@ -0,0 +1,634 @@
+package webserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type dummyRequestDecoder struct {
+	contentType string
+}
+
+func (decoder *dummyRequestDecoder) ContentType() string {
+	return decoder.contentType
+}
+
+func (decoder *dummyRequestDecoder) Decode(contentType string, body string, dataTemplate any) error {
+	return nil
+}
+
+func createDummyMultipartBody(t *testing.T) (string, string) {
+	var buffer = &bytes.Buffer{}
+	var writer = multipart.NewWriter(buffer)
+	writer.WriteField("name", "some name")
+	writer.WriteField("tags", "a")
+	writer.WriteField("tags", "b")
+	var part, _ = writer.CreateFormFile("file", "some file")
+	part.Write([]byte("some content"))
+	assert.NoError(t, writer.Close())
+	return writer.FormDataContentType(), buffer.String()
+}
+
+func TestFormRequestDecoder_ParseError(t *testing.T) {
+	// arrange
+	var dummyValues url.Values
+
+	// SUT
+	var sut = &FormRequestDecoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var err = sut.Decode(
+		ContentTypeForm,
+		"foo=%zz",
+		&dummyValues,
+	)
+
+	// assert
+	assert.Equal(t, ContentTypeForm, contentType)
+	assert.Error(t, err)
+	assert.Nil(t, dummyValues)
+}
+
+func TestFormRequestDecoder_HappyPath(t *testing.T) {
+	// arrange
+	var dummyValues = url.Values{"foo": {"bar"}}
+	var dummyDataTemplate url.Values
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(decodeForm).Expects(dummyValues, map[string][]*multipart.FileHeader(nil), &dummyDataTemplate).Returns(dummyError).Once()
+
+	// SUT
+	var sut = &FormRequestDecoder{}
+
+	// act
+	var err = sut.Decode(
+		ContentTypeForm,
+		"foo=bar",
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestMultipartRequestDecoder_InvalidContentType(t *testing.T) {
+	// arrange
+	var dummyForm multipart.Form
+
+	// SUT
+	var sut = &MultipartRequestDecoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var err = sut.Decode(
+		"invalid/;",
+		"some body",
+		&dummyForm,
+	)
+
+	// assert
+	assert.Equal(t, ContentTypeMultipart, contentType)
+	assert.Error(t, err)
+}
+
+func TestMultipartRequestDecoder_MissingBoundary(t *testing.T) {
+	// arrange
+	var dummyForm multipart.Form
+
+	// SUT
+	var sut = &MultipartRequestDecoder{}
+
+	// act
+	var err = sut.Decode(
+		ContentTypeMultipart,
+		"some body",
+		&dummyForm,
+	)
+
+	// assert
+	assert.Equal(t, http.ErrMissingBoundary, err)
+}
+
+func TestMultipartRequestDecoder_FormError(t *testing.T) {
+	// arrange
+	var dummyForm multipart.Form
+
+	// SUT
+	var sut = &MultipartRequestDecoder{}
+
+	// act
+	var err = sut.Decode(
+		ContentTypeMultipart+"; boundary=abc",
+		"some body",
+		&dummyForm,
+	)
+
+	// assert
+	assert.Error(t, err)
+}
+
+func TestMultipartRequestDecoder_Form(t *testing.T) {
+	// arrange
+	var dummyContentType, dummyBody = createDummyMultipartBody(t)
+	var dummyForm multipart.Form
+
+	// SUT
+	var sut = &MultipartRequestDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyContentType,
+		dummyBody,
+		&dummyForm,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"name": {"some name"}, "tags": {"a", "b"}}, dummyForm.Value)
+	assert.Equal(t, "some file", dummyForm.File["file"][0].Filename)
+}
+
+func TestMultipartRequestDecoder_Struct(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Name string                `form:"name"`
+		Tags []string              `form:"tags"`
+		File *multipart.FileHeader `form:"file"`
+	}
+	var dummyContentType, dummyBody = createDummyMultipartBody(t)
+	var dummyDataTemplate dummyTemplate
+
+	// SUT
+	var sut = &MultipartRequestDecoder{}
+
+	// act
+	var err = sut.Decode(
+		dummyContentType,
+		dummyBody,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "some name", dummyDataTemplate.Name)
+	assert.Equal(t, []string{"a", "b"}, dummyDataTemplate.Tags)
+	assert.Equal(t, "some file", dummyDataTemplate.File.Filename)
+	var file, _ = dummyDataTemplate.File.Open()
+	var content, _ = io.ReadAll(file)
+	assert.Equal(t, "some content", string(content))
+}
+
+func TestMultipartRequestDecoder_DecodeForSession_FormError(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyForm multipart.Form
+
+	// SUT
+	var sut = &MultipartRequestDecoder{}
+
+	// act
+	var err = sut.decodeForSession(
+		dummySession,
+		ContentTypeMultipart,
+		"some body",
+		&dummyForm,
+	)
+
+	// assert
+	assert.Equal(t, http.ErrMissingBoundary, err)
+	assert.Empty(t, dummySession.cleanups)
+}
+
+func TestMultipartRequestDecoder_DecodeForSession_HappyPath(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyContentType, dummyBody = createDummyMultipartBody(t)
+	var dummyForm multipart.Form
+
+	// SUT
+	var sut = &MultipartRequestDecoder{}
+
+	// act
+	var err = sut.decodeForSession(
+		dummySession,
+		dummyContentType,
+		dummyBody,
+		&dummyForm,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "some file", dummyForm.File["file"][0].Filename)
+	assert.Len(t, dummySession.cleanups, 1)
+	dummySession.cleanups[0]()
+}
+
+func TestXMLRequestDecoder(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Foo string `xml:"foo"`
+	}
+	var dummyDataTemplate dummyTemplate
+
+	// SUT
+	var sut = &XMLRequestDecoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var err = sut.Decode(
+		ContentTypeXML,
+		"<dummyTemplate><foo>bar</foo></dummyTemplate>",
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, ContentTypeXML, contentType)
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", dummyDataTemplate.Foo)
+}
+
+func TestGetFormFieldName(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Foo  string
+		Bar  string `form:"bar,omitempty"`
+		Skip string `form:"-"`
+	}
+	var dummyType = reflect.TypeOf(dummyTemplate{})
+
+	// assert
+	assert.Equal(t, "Foo", getFormFieldName(dummyType.Field(0)))
+	assert.Equal(t, "bar", getFormFieldName(dummyType.Field(1)))
+	assert.Equal(t, "-", getFormFieldName(dummyType.Field(2)))
+}
+
+func TestDecodeFormField_FileHeader(t *testing.T) {
+	// arrange
+	var dummyFileHeader1 = &multipart.FileHeader{Filename: "some file 1"}
+	var dummyFileHeader2 = &multipart.FileHeader{Filename: "some file 2"}
+	var dummyField1 *multipart.FileHeader
+	var dummyField2 *multipart.FileHeader
+	var dummyField3 []*multipart.FileHeader
+
+	// SUT + act
+	var err1 = decodeFormField(
+		reflect.ValueOf(&dummyField1).Elem(),
+		nil,
+		[]*multipart.FileHeader{dummyFileHeader1, dummyFileHeader2},
+	)
+	var err2 = decodeFormField(
+		reflect.ValueOf(&dummyField2).Elem(),
+		nil,
+		nil,
+	)
+	var err3 = decodeFormField(
+		reflect.ValueOf(&dummyField3).Elem(),
+		nil,
+		[]*multipart.FileHeader{dummyFileHeader1, dummyFileHeader2},
+	)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.Equal(t, dummyFileHeader1, dummyField1)
+	assert.NoError(t, err2)
+	assert.Nil(t, dummyField2)
+	assert.NoError(t, err3)
+	assert.Equal(t, []*multipart.FileHeader{dummyFileHeader1, dummyFileHeader2}, dummyField3)
+}
+
+func TestDecodeFormField_Values(t *testing.T) {
+	// arrange
+	var dummyField1 int
+	var dummyField2 []byte
+	var dummyField3 []int
+	var dummyField4 = "some default"
+
+	// SUT + act
+	var err1 = decodeFormField(
+		reflect.ValueOf(&dummyField1).Elem(),
+		[]string{"123", "456"},
+		nil,
+	)
+	var err2 = decodeFormField(
+		reflect.ValueOf(&dummyField2).Elem(),
+		[]string{"some bytes"},
+		nil,
+	)
+	var err3 = decodeFormField(
+		reflect.ValueOf(&dummyField3).Elem(),
+		[]string{"123", "456"},
+		nil,
+	)
+	var err4 = decodeFormField(
+		reflect.ValueOf(&dummyField4).Elem(),
+		nil,
+		nil,
+	)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.Equal(t, 123, dummyField1)
+	assert.NoError(t, err2)
+	assert.Equal(t, []byte("some bytes"), dummyField2)
+	assert.NoError(t, err3)
+	assert.Equal(t, []int{123, 456}, dummyField3)
+	assert.NoError(t, err4)
+	assert.Equal(t, "some default", dummyField4)
+}
+
+func TestDecodeFormField_SliceError(t *testing.T) {
+	// arrange
+	var dummyField []int
+
+	// SUT + act
+	var err = decodeFormField(
+		reflect.ValueOf(&dummyField).Elem(),
+		[]string{"123", "abc"},
+		nil,
+	)
+
+	// assert
+	assert.Error(t, err)
+	assert.Nil(t, dummyField)
+}
+
+func TestDecodeForm_Values(t *testing.T) {
+	// arrange
+	var dummyValues = url.Values{"foo": {"bar"}}
+	var dummyDataTemplate1 url.Values
+	var dummyDataTemplate2 map[string][]string
+
+	// SUT + act
+	var err1 = decodeForm(
+		dummyValues,
+		nil,
+		&dummyDataTemplate1,
+	)
+	var err2 = decodeForm(
+		dummyValues,
+		nil,
+		&dummyDataTemplate2,
+	)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.Equal(t, dummyValues, dummyDataTemplate1)
+	assert.NoError(t, err2)
+	assert.Equal(t, map[string][]string(dummyValues), dummyDataTemplate2)
+}
+
+func TestDecodeForm_InvalidTemplate(t *testing.T) {
+	// arrange
+	var dummyValues = url.Values{"foo": {"bar"}}
+	var dummyDataTemplate string
+
+	// SUT + act
+	var err = decodeForm(
+		dummyValues,
+		nil,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.EqualError(t, err, "unable to decode form into data template of type *string")
+}
+
+func TestDecodeForm_Struct(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Foo     int
+		Bar     int    `form:"bar"`
+		Test    string `form:"test"`
+		Skip    string `form:"-"`
+		private string
+	}
+	var dummyValues = url.Values{
+		"Foo":     {"abc"},
+		"bar":     {"xyz"},
+		"test":    {"some test"},
+		"Skip":    {"some skip"},
+		"-":       {"some skip"},
+		"private": {"some private"},
+	}
+	var dummyDataTemplate dummyTemplate
+
+	// SUT + act
+	var err = decodeForm(
+		dummyValues,
+		nil,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.EqualError(t, err, "unable to decode form field [Foo]: unable to unmarshal value [abc] into data template\nunable to decode form field [bar]: unable to unmarshal value [xyz] into data template")
+	assert.Equal(t, "some test", dummyDataTemplate.Test)
+	assert.Empty(t, dummyDataTemplate.Skip)
+	assert.Empty(t, dummyDataTemplate.private)
+}
+
+func TestGetRequestDecoder_InvalidContentType(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+
+	// SUT + act
+	var result = getRequestDecoder(
+		dummySession,
+		"",
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestGetRequestDecoder_NoMatch(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+
+	// SUT + act
+	var result = getRequestDecoder(
+		dummySession,
+		ContentTypeJSON,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestGetRequestDecoder_Match(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyDecoder = &dummyRequestDecoder{contentType: "application/x-msgpack"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).RequestDecoders).Expects(dummyCustomization).Returns([]RequestDecoder{nil, &XMLRequestDecoder{}, dummyDecoder}).Once()
+
+	// SUT + act
+	var result = getRequestDecoder(
+		dummySession,
+		"application/x-msgpack; charset=utf-8",
+	)
+
+	// assert
+	assert.Equal(t, dummyDecoder, result)
+}
+
+func TestIsStructuredTemplate(t *testing.T) {
+	// arrange
+	var dummyString string
+	var dummyBytes []byte
+	var dummyStruct struct{ Name string }
+	var dummyValues url.Values
+	var dummyMap map[string]any
+
+	// act + assert
+	assert.False(t, isStructuredTemplate(nil))
+	assert.False(t, isStructuredTemplate(dummyStruct))
+	assert.False(t, isStructuredTemplate(&dummyString))
+	assert.False(t, isStructuredTemplate(&dummyBytes))
+	assert.True(t, isStructuredTemplate(&dummyStruct))
+	assert.True(t, isStructuredTemplate(&dummyValues))
+	assert.True(t, isStructuredTemplate(&dummyMap))
+}
+
+func TestDecodeRequestBody_NotStructured(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"Content-Type": {"application/xml"}},
+	}
+	var dummyRequestBody = "some request body"
+	var dummyDataTemplate string
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(tryUnmarshal).Expects(dummyRequestBody, &dummyDataTemplate).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = decodeRequestBody(
+		dummySession,
+		dummyHTTPRequest,
+		dummyRequestBody,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestDecodeRequestBody_NoDecoder(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyContentType = "some content type"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"Content-Type": {dummyContentType}},
+	}
+	var dummyRequestBody = "some request body"
+	var dummyDataTemplate struct{ Name string }
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRequestDecoder).Expects(dummySession, dummyContentType).Returns(nil).Once()
+	m.Mock(tryUnmarshal).Expects(dummyRequestBody, &dummyDataTemplate).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = decodeRequestBody(
+		dummySession,
+		dummyHTTPRequest,
+		dummyRequestBody,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestDecodeRequestBody_SessionDecoder(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyContentType = "some content type"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"Content-Type": {dummyContentType}},
+	}
+	var dummyRequestBody = "some request body"
+	var dummyDataTemplate struct{ Name string }
+	var dummyDecoder = &MultipartRequestDecoder{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRequestDecoder).Expects(dummySession, dummyContentType).Returns(dummyDecoder).Once()
+	m.Mock((*MultipartRequestDecoder).decodeForSession).Expects(dummyDecoder, dummySession, dummyContentType, dummyRequestBody, &dummyDataTemplate).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = decodeRequestBody(
+		dummySession,
+		dummyHTTPRequest,
+		dummyRequestBody,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestDecodeRequestBody_Decoder(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyContentType = "some content type"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"Content-Type": {dummyContentType}},
+	}
+	var dummyRequestBody = "some request body"
+	var dummyDataTemplate struct{ Name string }
+	var dummyDecoder = &dummyRequestDecoder{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRequestDecoder).Expects(dummySession, dummyContentType).Returns(dummyDecoder).Once()
+	m.Mock((*dummyRequestDecoder).Decode).Expects(dummyDecoder, dummyContentType, dummyRequestBody, &dummyDataTemplate).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = decodeRequestBody(
+		dummySession,
+		dummyHTTPRequest,
+		dummyRequestBody,
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
@@ -0,0 +1,172 @@
+package webserver
+
+import (
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ResponseEncoder encodes response contents into the raw bytes of a specific content type, to be negotiated against the Accept header of the incoming request
+type ResponseEncoder interface {
+	// ContentType returns the content type of the encoded bytes, which is also matched against the Accept header of the incoming request
+	ContentType() string
+	// Encode encodes the given response content into raw bytes
+	Encode(responseContent any) ([]byte, error)
+}
+
+// JSONResponseEncoder is the built-in ResponseEncoder for application/json, which is also the default when no encoder matches the Accept header
+type JSONResponseEncoder struct{}
+
+// ContentType returns the content type of the encoded bytes, which is also matched against the Accept header of the incoming request
+func (encoder *JSONResponseEncoder) ContentType() string {
+	return ContentTypeJSON
+}
+
+// Encode encodes the given response content into raw bytes
+func (encoder *JSONResponseEncoder) Encode(responseContent any) ([]byte, error) {
+	return json.Marshal(
+		responseContent,
+	)
+}
+
+// XMLResponseEncoder is the built-in ResponseEncoder for application/xml
+type XMLResponseEncoder struct{}
+
+// ContentType returns the content type of the encoded bytes, which is also matched against the Accept header of the incoming request
+func (encoder *XMLResponseEncoder) ContentType() string {
+	return ContentTypeXML
+}
+
+// Encode encodes the given response content into raw bytes
+func (encoder *XMLResponseEncoder) Encode(responseContent any) ([]byte, error) {
+	return xml.Marshal(
+		responseContent,
+	)
+}
+
+// TextResponseEncoder is the built-in ResponseEncoder for text/plain, writing strings and byte slices as is and formatting other contents with their default format
+type TextResponseEncoder struct{}
+
+// ContentType returns the content type of the encoded bytes, which is also matched against the Accept header of the incoming request
+func (encoder *TextResponseEncoder) ContentType() string {
+	return ContentTypeText
+}
+
+// Encode encodes the given response content into raw bytes
+func (encoder *TextResponseEncoder) Encode(responseContent any) ([]byte, error) {
+	switch typedContent := responseContent.(type) {
+	case []byte:
+		return typedContent, nil
+	case string:
+		return []byte(typedContent), nil
+	}
+	return []byte(
+		fmt.Sprint(
+			responseContent,
+		),
+	), nil
+}
+
+var (
+	defaultResponseEncoder ResponseEncoder = &JSONResponseEncoder{}
+)
+
+type acceptedMediaType struct {
+	mediaType string
+	quality   float64
+}
+
+// parseAcceptHeader returns the media types listed in the given Accept header value, ordered by their quality values from high to low
+func parseAcceptHeader(acceptHeader string) []string {
+	var acceptedMediaTypes []acceptedMediaType
+	for _, mediaRange := range strings.Split(acceptHeader, ",") {
+		var mediaType, parameters, parseError = mime.ParseMediaType(
+			strings.TrimSpace(
+				mediaRange,
+			),
+		)
+		if parseError != nil {
+			continue
+		}
+		var quality, qualityError = strconv.ParseFloat(
+			parameters["q"],
+			64,
+		)
+		if qualityError != nil {
+			quality = 1
+		}
+		if quality <= 0 {
+			continue
+		}
+		acceptedMediaTypes = append(
+			acceptedMediaTypes,
+			acceptedMediaType{
+				mediaType: mediaType,
+				quality:   quality,
+			},
+		)
+	}
+	slices.SortStableFunc(
+		acceptedMediaTypes,
+		func(left, right acceptedMediaType) int {
+			return cmp.Compare(right.quality, left.quality)
+		},
+	)
+	var mediaTypes = []string{}
+	for _, acceptedMediaType := range acceptedMediaTypes {
+		mediaTypes = append(
+			mediaTypes,
+			acceptedMediaType.mediaType,
+		)
+	}
+	return mediaTypes
+}
+
+func isMediaTypeMatch(mediaRange string, contentType string) bool {
+	var mediaType, _, parseError = mime.ParseMediaType(
+		contentType,
+	)
+	if parseError != nil {
+		return false
+	}
+	if mediaRange == "*/*" ||
+		mediaRange == mediaType {
+		return true
+	}
+	var mainType, isWildcard = strings.CutSuffix(
+		mediaRange,
+		"/*",
+	)
+	return isWildcard &&
+		strings.HasPrefix(
+			mediaType,
+			mainType+"/",
+		)
+}
+
+// negotiateResponseEncoder picks the first registered encoder matching the Accept header of the session's request in the order of preference; defaults to JSON encoder if none matches
+func negotiateResponseEncoder(session *session) ResponseEncoder {
+	var encoders = session.customization.ResponseEncoders()
+	var mediaTypes = parseAcceptHeader(
+		session.GetRequest().Header.Get("Accept"),
+	)
+	for _, mediaType := range mediaTypes {
+		for _, encoder := range encoders {
+			if isInterfaceValueNil(encoder) {
+				continue
+			}
+			if isMediaTypeMatch(
+				mediaType,
+				encoder.ContentType(),
+			) {
+				return encoder
+			}
+		}
+	}
+	return defaultResponseEncoder
+}
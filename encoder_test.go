@@ -0,0 +1,158 @@
+package webserver
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type dummyResponseEncoder struct {
+	contentType string
+}
+
+func (encoder *dummyResponseEncoder) ContentType() string {
+	return encoder.contentType
+}
+
+func (encoder *dummyResponseEncoder) Encode(responseContent any) ([]byte, error) {
+	return nil, nil
+}
+
+func TestJSONResponseEncoder(t *testing.T) {
+	// SUT
+	var sut = &JSONResponseEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var body, err = sut.Encode(map[string]int{"foo": 1})
+
+	// assert
+	assert.Equal(t, ContentTypeJSON, contentType)
+	assert.Equal(t, `{"foo":1}`, string(body))
+	assert.NoError(t, err)
+}
+
+func TestXMLResponseEncoder(t *testing.T) {
+	// arrange
+	type dummyContent struct {
+		Foo string `xml:"foo"`
+	}
+
+	// SUT
+	var sut = &XMLResponseEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var body, err = sut.Encode(dummyContent{Foo: "bar"})
+
+	// assert
+	assert.Equal(t, ContentTypeXML, contentType)
+	assert.Equal(t, "<dummyContent><foo>bar</foo></dummyContent>", string(body))
+	assert.NoError(t, err)
+}
+
+func TestTextResponseEncoder(t *testing.T) {
+	// SUT
+	var sut = &TextResponseEncoder{}
+
+	// act
+	var contentType = sut.ContentType()
+	var body1, err1 = sut.Encode([]byte("some bytes"))
+	var body2, err2 = sut.Encode("some string")
+	var body3, err3 = sut.Encode(errors.New("some error"))
+
+	// assert
+	assert.Equal(t, ContentTypeText, contentType)
+	assert.Equal(t, "some bytes", string(body1))
+	assert.NoError(t, err1)
+	assert.Equal(t, "some string", string(body2))
+	assert.NoError(t, err2)
+	assert.Equal(t, "some error", string(body3))
+	assert.NoError(t, err3)
+}
+
+func TestParseAcceptHeader_Empty(t *testing.T) {
+	// SUT + act
+	var result = parseAcceptHeader(
+		"",
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestParseAcceptHeader_Ordered(t *testing.T) {
+	// arrange
+	var dummyAcceptHeader = "text/html;q=0.5, application/xml;q=0.9, invalid/;, text/plain;q=0, application/json, */*;q=0.1, text/*;q=abc"
+
+	// SUT + act
+	var result = parseAcceptHeader(
+		dummyAcceptHeader,
+	)
+
+	// assert
+	assert.Equal(t, []string{"application/json", "text/*", "application/xml", "text/html", "*/*"}, result)
+}
+
+func TestIsMediaTypeMatch(t *testing.T) {
+	// assert
+	assert.False(t, isMediaTypeMatch("*/*", "invalid/;"))
+	assert.True(t, isMediaTypeMatch("*/*", ContentTypeXML))
+	assert.True(t, isMediaTypeMatch("application/xml", ContentTypeXML))
+	assert.True(t, isMediaTypeMatch("application/*", ContentTypeXML))
+	assert.False(t, isMediaTypeMatch("text/*", ContentTypeXML))
+	assert.False(t, isMediaTypeMatch("application/json", ContentTypeXML))
+	assert.False(t, isMediaTypeMatch("app/*", ContentTypeXML))
+}
+
+func TestNegotiateResponseEncoder_NoMatch(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		request: &http.Request{
+			Header: http.Header{
+				"Accept": {"text/html, image/*"},
+			},
+		},
+		customization: dummyCustomization,
+	}
+
+	// SUT + act
+	var result = negotiateResponseEncoder(
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, defaultResponseEncoder, result)
+}
+
+func TestNegotiateResponseEncoder_Match(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		request: &http.Request{
+			Header: http.Header{
+				"Accept": {"text/html, application/x-msgpack;q=0.8, application/*;q=0.5"},
+			},
+		},
+		customization: dummyCustomization,
+	}
+	var dummyEncoder = &dummyResponseEncoder{contentType: "application/x-msgpack"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).ResponseEncoders).Expects(dummyCustomization).Returns([]ResponseEncoder{nil, &JSONResponseEncoder{}, dummyEncoder}).Once()
+
+	// SUT + act
+	var result = negotiateResponseEncoder(
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, dummyEncoder, result)
+}
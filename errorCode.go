@@ -6,20 +6,28 @@ import (
 
 // web server built-in error messages
 const (
-	errorMessageSessionNil          = "The session object is nil"
-	errorMessageRouteRegistration   = "The route registration failed"
-	errorMessageHostServer          = "The server hosting failed"
-	errorMessageRequestBodyEmpty    = "The request body is empty"
-	errorMessageRequestBodyInvalid  = "The request body is invalid"
-	errorMessageParameterNotFound   = "The request parameter is not found"
-	errorMessageParameterInvalid    = "The request parameter is invalid"
-	errorMessageQueryNotFound       = "The request query is not found"
-	errorMessageQueryInvalid        = "The request query is invalid"
-	errorMessageHeaderNotFound      = "The request header is not found"
-	errorMessageHeaderInvalid       = "The request header is invalid"
-	errorMessageWebRequestNil       = "The web request object is nil"
-	errorMessageResponseInvalid     = "The response body is invalid"
-	errorMessageDataTemplateInvalid = "The data templated is not a pointer"
+	errorMessageSessionNil               = "The session object is nil"
+	errorMessageRouteRegistration        = "The route registration failed"
+	errorMessageHostServer               = "The server hosting failed"
+	errorMessageRequestBodyEmpty         = "The request body is empty"
+	errorMessageRequestBodyInvalid       = "The request body is invalid"
+	errorMessageParameterNotFound        = "The request parameter is not found"
+	errorMessageParameterInvalid         = "The request parameter is invalid"
+	errorMessageQueryNotFound            = "The request query is not found"
+	errorMessageQueryInvalid             = "The request query is invalid"
+	errorMessageHeaderNotFound           = "The request header is not found"
+	errorMessageHeaderInvalid            = "The request header is invalid"
+	errorMessageWebRequestNil            = "The web request object is nil"
+	errorMessageResponseInvalid          = "The response body is invalid"
+	errorMessageDataTemplateInvalid      = "The data templated is not a pointer"
+	errorMessageWebcallCancelled         = "The web request is cancelled"
+	errorMessageWebcallTimeout           = "The web request is timed out"
+	errorMessageRequestBodyNotReplayable = "The web request body cannot be replayed"
+	errorMessageResponseStreamFailed     = "The response body streaming failed"
+	errorMessageWebcallPayloadInvalid    = "The web request payload is invalid"
+	errorMessageRequestValidationFailed  = "The request validation failed"
+	errorMessageRequestBindingFailed     = "The request binding failed"
+	errorMessageClientCertRequired       = "The route requires a verified client certificate"
 )
 
 type errorCode string
@@ -36,6 +44,8 @@ const (
 	errorCodeAccessForbidden  errorCode = "AccessForbidden"
 	errorCodeDataCorruption   errorCode = "DataCorruption"
 	errorCodeNotImplemented   errorCode = "NotImplemented"
+	errorCodeRequestCancelled errorCode = "RequestCancelled"
+	errorCodeRequestTimeout   errorCode = "RequestTimeout"
 )
 
 func (errorCode errorCode) httpStatusCode() int {
@@ -61,6 +71,10 @@ func (errorCode errorCode) httpStatusCode() int {
 		statusCode = http.StatusConflict
 	case errorCodeNotImplemented:
 		statusCode = http.StatusNotImplemented
+	case errorCodeRequestCancelled:
+		statusCode = http.StatusRequestTimeout
+	case errorCodeRequestTimeout:
+		statusCode = http.StatusGatewayTimeout
 	default:
 		statusCode = http.StatusInternalServerError
 	}
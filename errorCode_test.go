@@ -117,6 +117,28 @@ func TestErrorCodeEnumHTTPStatusCode_NotImplemented(t *testing.T) {
 	assert.Equal(t, http.StatusNotImplemented, result)
 }
 
+func TestErrorCodeEnumHTTPStatusCode_RequestCancelled(t *testing.T) {
+	// SUT
+	var dummyErrorCode = errorCodeRequestCancelled
+
+	// act
+	var result = dummyErrorCode.httpStatusCode()
+
+	// assert
+	assert.Equal(t, http.StatusRequestTimeout, result)
+}
+
+func TestErrorCodeEnumHTTPStatusCode_RequestTimeout(t *testing.T) {
+	// SUT
+	var dummyErrorCode = errorCodeRequestTimeout
+
+	// act
+	var result = dummyErrorCode.httpStatusCode()
+
+	// assert
+	assert.Equal(t, http.StatusGatewayTimeout, result)
+}
+
 func TestErrorCodeEnumHTTPStatusCode_OtherErrorCode(t *testing.T) {
 	// SUT
 	var dummyErrorCode = errorCode("some other error code")
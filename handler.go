@@ -16,7 +16,7 @@ func initiateSession(
 		httpRequest,
 		app.actionFuncMap,
 	)
-	return &session{
+	var session = &session{
 		uuid.New(),
 		name,
 		method,
@@ -25,7 +25,30 @@ func initiateSession(
 		responseWriter,
 		map[string]any{},
 		app.customization,
-	}, action, routeError
+		getTimeNowUTC(),
+		0,
+		nil,
+		getRequestLogFilter(
+			app.customization,
+			httpRequest,
+		),
+		false,
+		extractTraceContext(
+			httpRequest,
+		),
+		nil,
+		nil,
+	}
+	session.logBodyUnsampled = !isLogBodySampled(
+		session,
+	)
+	startServerSpan(
+		session,
+	)
+	beginSessionMetrics(
+		session,
+	)
+	return session, action, routeError
 }
 
 func finalizeSession(
@@ -38,12 +61,24 @@ func finalizeSession(
 		recoverResult,
 	)
 	var method, pattern = extractRouteMethodAndPattern(session.name)
+	var duration = time.Since(startTime)
 	logEndpointExit(
 		session,
 		pattern,
 		method,
 		"%s",
-		time.Since(startTime),
+		duration,
+	)
+	finishServerTelemetry(
+		session,
+		duration,
+	)
+	finishSessionMetrics(
+		session,
+		duration,
+	)
+	releaseSession(
+		session,
 	)
 }
 
@@ -94,11 +129,14 @@ func (app *application) handleSession(
 		method,
 		"",
 	)
-	defer finalizeSession(
-		session,
-		getTimeNowUTC(),
-		recover(),
-	)
+	var startTime = getTimeNowUTC()
+	defer func() {
+		finalizeSession(
+			session,
+			startTime,
+			recover(),
+		)
+	}()
 	if routeError != nil {
 		writeResponse(
 			session,
@@ -107,6 +145,18 @@ func (app *application) handleSession(
 		)
 		return
 	}
+	var clientCertError = verifyClientCertRequirement(
+		app,
+		session,
+	)
+	if clientCertError != nil {
+		writeResponse(
+			session,
+			nil,
+			clientCertError,
+		)
+		return
+	}
 	handleAction(
 		session,
 		action,
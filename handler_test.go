@@ -46,6 +46,9 @@ func TestInitiateSession(t *testing.T) {
 	var dummyPattern = "some pattern"
 	var dummyRouteError = errors.New("some route error")
 	var dummySessionID = uuid.New()
+	var dummyStartTime = time.Now()
+	var dummyLogFilter = &LogFilter{LogType: LogTypeGeneralTracing, LogLevel: LogLevelWarn}
+	var dummyTrace = traceContext{traceID: "some trace ID", spanID: "some span ID"}
 
 	// mock
 	var m = gomocker.NewMocker(t)
@@ -54,6 +57,12 @@ func TestInitiateSession(t *testing.T) {
 	m.Mock(uuid.New).Expects().Returns(dummySessionID).Once()
 	m.Mock(getRouteInfo).Expects(dummyHTTPRequest, dummyActionFuncMap).
 		Returns(dummyName, dummyMethod, dummyPattern, dummyAction, dummyRouteError).Once()
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock(getRequestLogFilter).Expects(dummyCustomization, dummyHTTPRequest).Returns(dummyLogFilter).Once()
+	m.Mock(extractTraceContext).Expects(dummyHTTPRequest).Returns(dummyTrace).Once()
+	m.Mock(isLogBodySampled).Expects(gomocker.Anything()).Returns(false).Once()
+	m.Mock(startServerSpan).Expects(gomocker.Anything()).Returns().Once()
+	m.Mock(beginSessionMetrics).Expects(gomocker.Anything()).Returns().Once()
 
 	// SUT + act
 	var session, action, err = initiateSession(
@@ -72,6 +81,13 @@ func TestInitiateSession(t *testing.T) {
 	assert.Equal(t, dummyResponseWriter, session.responseWriter)
 	assert.Empty(t, session.attachment)
 	assert.Equal(t, dummyCustomization, session.customization)
+	assert.Equal(t, dummyStartTime, session.startTime)
+	assert.Zero(t, session.statusCode)
+	assert.Empty(t, session.logAttributes)
+	assert.Equal(t, dummyLogFilter, session.logFilter)
+	assert.True(t, session.logBodyUnsampled)
+	assert.Equal(t, dummyTrace, session.trace)
+	assert.Nil(t, session.span)
 	assertFunctionEquals(t, dummyAction, action)
 	assert.Equal(t, dummyRouteError, err)
 }
@@ -101,6 +117,9 @@ func TestFinalizeSession(t *testing.T) {
 	m.Mock(logEndpointExit).Expects(dummySession, dummyPattern, dummyMethod,
 		"%s", dummyDuration).Returns().Once()
 	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyDuration).Once()
+	m.Mock(finishServerTelemetry).Expects(dummySession, dummyDuration).Returns().Once()
+	m.Mock(finishSessionMetrics).Expects(dummySession, dummyDuration).Returns().Once()
+	m.Mock(releaseSession).Expects(dummySession).Returns().Once()
 
 	// SUT + act
 	finalizeSession(
@@ -188,7 +207,7 @@ func TestHandleSession_RouteError(t *testing.T) {
 	m.Mock(extractRouteMethodAndPattern).Expects(dummyName).Returns(dummyMethod, dummyPattern).Once()
 	m.Mock(logEndpointEnter).Expects(dummySession, dummyPattern, dummyMethod, "").Returns().Once()
 	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
-	m.Mock(finalizeSession).Expects(dummySession, dummyStartTime, recover()).Returns().Once()
+	m.Mock(finalizeSession).Expects(dummySession, dummyStartTime, nil).Returns().Once()
 	m.Mock(writeResponse).Expects(dummySession, nil, dummyRouteError).Returns().Once()
 
 	// SUT + act
@@ -198,6 +217,42 @@ func TestHandleSession_RouteError(t *testing.T) {
 	)
 }
 
+func TestHandleSession_ClientCertError(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyMethod = "some method"
+	var dummyHTTPRequest = &http.Request{
+		Method: dummyMethod,
+	}
+	var dummyName = "some name"
+	var dummySession = &session{
+		name: dummyName,
+	}
+	var dummyPattern = "some pattern"
+	var dummyAction = func(session Session) (any, error) { return nil, nil }
+	var dummyStartTime = time.Now()
+	var dummyClientCertError = errors.New("some client cert error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyResponseWriter, dummyHTTPRequest).Returns(dummySession, dummyAction, nil).Once()
+	m.Mock(extractRouteMethodAndPattern).Expects(dummyName).Returns(dummyMethod, dummyPattern).Once()
+	m.Mock(logEndpointEnter).Expects(dummySession, dummyPattern, dummyMethod, "").Returns().Once()
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock(finalizeSession).Expects(dummySession, dummyStartTime, nil).Returns().Once()
+	m.Mock(verifyClientCertRequirement).Expects(dummyApplication, dummySession).Returns(dummyClientCertError).Once()
+	m.Mock(writeResponse).Expects(dummySession, nil, dummyClientCertError).Returns().Once()
+
+	// SUT + act
+	dummyApplication.handleSession(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+}
+
 func TestHandleSession_Success(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{}
@@ -222,7 +277,8 @@ func TestHandleSession_Success(t *testing.T) {
 	m.Mock(extractRouteMethodAndPattern).Expects(dummyName).Returns(dummyMethod, dummyPattern).Once()
 	m.Mock(logEndpointEnter).Expects(dummySession, dummyPattern, dummyMethod, "").Returns().Once()
 	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
-	m.Mock(finalizeSession).Expects(dummySession, dummyStartTime, recover()).Returns().Once()
+	m.Mock(finalizeSession).Expects(dummySession, dummyStartTime, nil).Returns().Once()
+	m.Mock(verifyClientCertRequirement).Expects(dummyApplication, dummySession).Returns(nil).Once()
 	m.Mock(handleAction).Expects(dummySession, gomocker.Matches(func(value any) bool {
 		return functionPointerEquals(dummyAction, value)
 	})).Returns().Once()
@@ -233,3 +289,46 @@ func TestHandleSession_Success(t *testing.T) {
 		dummyHTTPRequest,
 	)
 }
+
+type dummyPanicCustomization struct {
+	DefaultCustomization
+}
+
+func (customization *dummyPanicCustomization) PreAction(session Session) error {
+	panic("some panic")
+}
+
+func TestHandleSession_Panic(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{}
+	var dummyResponseWriter = &dummyResponseWriter{}
+	var dummyMethod = "some method"
+	var dummyHTTPRequest = &http.Request{
+		Method: dummyMethod,
+	}
+	var dummyName = "some name"
+	var dummySession = &session{
+		name:          dummyName,
+		customization: &dummyPanicCustomization{},
+	}
+	var dummyPattern = "some pattern"
+	var dummyAction = func(session Session) (any, error) { return nil, nil }
+	var dummyStartTime = time.Now()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(initiateSession).Expects(dummyApplication, dummyResponseWriter, dummyHTTPRequest).Returns(dummySession, dummyAction, nil).Once()
+	m.Mock(extractRouteMethodAndPattern).Expects(dummyName).Returns(dummyMethod, dummyPattern).Once()
+	m.Mock(logEndpointEnter).Expects(dummySession, dummyPattern, dummyMethod, "").Returns().Once()
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock(verifyClientCertRequirement).Expects(dummyApplication, dummySession).Returns(nil).Once()
+	m.Mock(finalizeSession).Expects(dummySession, dummyStartTime, "some panic").Returns().Once()
+
+	// SUT + act
+	dummyApplication.handleSession(
+		dummyResponseWriter,
+		dummyHTTPRequest,
+	)
+}
@@ -0,0 +1,127 @@
+package webserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// These are the paths of the built-in health endpoints
+const (
+	healthPath    = "/healthz"
+	readinessPath = "/readyz"
+)
+
+// These are the statuses reported by the built-in health endpoints
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+)
+
+// HealthCheck is a named check reported by the built-in health endpoints; the check returns nil when healthy, or the reason of being unhealthy otherwise
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// healthResponse is the JSON representation of the results of the built-in health endpoints
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// runHealthChecks runs all given checks, returning whether all of them pass and the result of each by name
+func runHealthChecks(ctx context.Context, healthChecks []HealthCheck) (bool, map[string]string) {
+	var isHealthy = true
+	var results = map[string]string{}
+	for _, healthCheck := range healthChecks {
+		if healthCheck.Check == nil {
+			continue
+		}
+		var checkError = healthCheck.Check(ctx)
+		if checkError != nil {
+			isHealthy = false
+			results[healthCheck.Name] = checkError.Error()
+		} else {
+			results[healthCheck.Name] = healthStatusOK
+		}
+	}
+	return isHealthy, results
+}
+
+func writeHealthResponse(
+	responseWriter http.ResponseWriter,
+	isHealthy bool,
+	results map[string]string,
+) {
+	var statusCode, status = http.StatusOK, healthStatusOK
+	if !isHealthy {
+		statusCode, status = http.StatusServiceUnavailable, healthStatusUnavailable
+	}
+	var responseBody, _ = json.Marshal(
+		healthResponse{
+			Status: status,
+			Checks: results,
+		},
+	)
+	responseWriter.Header().Set("Content-Type", ContentTypeJSON)
+	responseWriter.WriteHeader(statusCode)
+	responseWriter.Write(responseBody)
+}
+
+// handleHealth reports liveness by the customized health checks, regardless of the application being shut down
+func (app *application) handleHealth(
+	responseWriter http.ResponseWriter,
+	httpRequest *http.Request,
+) {
+	var isHealthy, results = runHealthChecks(
+		httpRequest.Context(),
+		app.customization.HealthChecks(),
+	)
+	writeHealthResponse(
+		responseWriter,
+		isHealthy,
+		results,
+	)
+}
+
+// handleReadiness reports readiness by the customized readiness checks, failing as soon as the application stops running, e.g. once the shutdown signal is received
+func (app *application) handleReadiness(
+	responseWriter http.ResponseWriter,
+	httpRequest *http.Request,
+) {
+	if !app.IsRunning() {
+		writeHealthResponse(
+			responseWriter,
+			false,
+			nil,
+		)
+		return
+	}
+	var isReady, results = runHealthChecks(
+		httpRequest.Context(),
+		app.customization.ReadinessChecks(),
+	)
+	writeHealthResponse(
+		responseWriter,
+		isReady,
+		results,
+	)
+}
+
+// registerHealthChecks registers the built-in health endpoints, before any customized routes so that routes of the same paths take precedence
+func registerHealthChecks(
+	app *application,
+	router chi.Router,
+) {
+	router.Get(
+		healthPath,
+		app.handleHealth,
+	)
+	router.Get(
+		readinessPath,
+		app.handleReadiness,
+	)
+}
@@ -0,0 +1,205 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type dummyHealthCustomization struct {
+	DefaultCustomization
+	healthChecks    []HealthCheck
+	readinessChecks []HealthCheck
+}
+
+func (customization *dummyHealthCustomization) HealthChecks() []HealthCheck {
+	return customization.healthChecks
+}
+
+func (customization *dummyHealthCustomization) ReadinessChecks() []HealthCheck {
+	return customization.readinessChecks
+}
+
+func TestRunHealthChecks_NoChecks(t *testing.T) {
+	// SUT + act
+	var isHealthy, results = runHealthChecks(
+		context.Background(),
+		nil,
+	)
+
+	// assert
+	assert.True(t, isHealthy)
+	assert.Empty(t, results)
+}
+
+func TestRunHealthChecks_MixedChecks(t *testing.T) {
+	// arrange
+	var dummyHealthChecks = []HealthCheck{
+		{Name: "database", Check: func(ctx context.Context) error { return nil }},
+		{Name: "cache", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+		{Name: "nothing"},
+	}
+
+	// SUT + act
+	var isHealthy, results = runHealthChecks(
+		context.Background(),
+		dummyHealthChecks,
+	)
+
+	// assert
+	assert.False(t, isHealthy)
+	assert.Equal(t, map[string]string{
+		"database": "ok",
+		"cache":    "connection refused",
+	}, results)
+}
+
+func TestWriteHealthResponse_Healthy(t *testing.T) {
+	// arrange
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	writeHealthResponse(
+		recorder,
+		true,
+		map[string]string{"database": "ok"},
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, ContentTypeJSON, recorder.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":"ok","checks":{"database":"ok"}}`, recorder.Body.String())
+}
+
+func TestWriteHealthResponse_Unhealthy(t *testing.T) {
+	// arrange
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	writeHealthResponse(
+		recorder,
+		false,
+		nil,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.JSONEq(t, `{"status":"unavailable"}`, recorder.Body.String())
+}
+
+func TestHandleHealth_IgnoresShutdown(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		customization: &dummyHealthCustomization{
+			healthChecks: []HealthCheck{
+				{Name: "self", Check: func(ctx context.Context) error { return nil }},
+			},
+		},
+	}
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	dummyApplication.handleHealth(
+		recorder,
+		httptest.NewRequest(http.MethodGet, healthPath, nil),
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"status":"ok","checks":{"self":"ok"}}`, recorder.Body.String())
+}
+
+func TestHandleReadiness_NotRunning(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		customization: &dummyHealthCustomization{
+			readinessChecks: []HealthCheck{
+				{Name: "database", Check: func(ctx context.Context) error { return nil }},
+			},
+		},
+	}
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	dummyApplication.handleReadiness(
+		recorder,
+		httptest.NewRequest(http.MethodGet, readinessPath, nil),
+	)
+
+	// assert
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.JSONEq(t, `{"status":"unavailable"}`, recorder.Body.String())
+}
+
+func TestHandleReadiness_CheckFailure(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		customization: &dummyHealthCustomization{
+			readinessChecks: []HealthCheck{
+				{Name: "database", Check: func(ctx context.Context) error { return errors.New("timeout") }},
+			},
+		},
+	}
+	dummyApplication.started.Store(true)
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	dummyApplication.handleReadiness(
+		recorder,
+		httptest.NewRequest(http.MethodGet, readinessPath, nil),
+	)
+
+	// assert
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.JSONEq(t, `{"status":"unavailable","checks":{"database":"timeout"}}`, recorder.Body.String())
+}
+
+func TestHandleReadiness_Ready(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		customization: &dummyHealthCustomization{},
+	}
+	dummyApplication.started.Store(true)
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	dummyApplication.handleReadiness(
+		recorder,
+		httptest.NewRequest(http.MethodGet, readinessPath, nil),
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, recorder.Body.String())
+}
+
+func TestRegisterHealthChecks(t *testing.T) {
+	// arrange
+	var dummyApplication = &application{
+		customization: &dummyHealthCustomization{},
+	}
+	dummyApplication.started.Store(true)
+	var dummyRouter = chi.NewRouter()
+	var healthRecorder = httptest.NewRecorder()
+	var readinessRecorder = httptest.NewRecorder()
+
+	// SUT + act
+	registerHealthChecks(
+		dummyApplication,
+		dummyRouter,
+	)
+	dummyRouter.Get(readinessPath, func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		responseWriter.WriteHeader(http.StatusTeapot)
+	})
+	dummyRouter.ServeHTTP(healthRecorder, httptest.NewRequest(http.MethodGet, healthPath, nil))
+	dummyRouter.ServeHTTP(readinessRecorder, httptest.NewRequest(http.MethodGet, readinessPath, nil))
+
+	// assert
+	assert.Equal(t, http.StatusOK, healthRecorder.Code)
+	assert.Equal(t, http.StatusTeapot, readinessRecorder.Code)
+}
@@ -0,0 +1,97 @@
+package webserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+)
+
+// defaultListenerName is the name of the listener targeting the address of the application
+const defaultListenerName = "default"
+
+// ListenerSetting holds the settings of an additional listener serving the same routes as the application, e.g. an internal plain HTTP port or a UDS
+type ListenerSetting struct {
+	// Name identifies the listener in logs
+	Name string
+	// Address is the TCP address to listen on, e.g. ":8080"; ignored if Listener is set
+	Address string
+	// Listener is the override of net.Listener to be used instead of the address, e.g. a UDS listener
+	Listener net.Listener
+	// ServerCert is the server certificate of the listener; also determines the hosting security option of the listener (HTTP v.s. HTTPS)
+	ServerCert *tls.Certificate
+	// CaCertPool is the CA cert pool for incoming client certificate validation on the listener; if nil, no validation is conducted for incoming client certificates
+	CaCertPool *x509.CertPool
+	// CertificateProvider provides the server certificate and CA cert pool of the listener on each TLS handshake; if set, ServerCert and CaCertPool are ignored
+	CertificateProvider CertificateProvider
+	// ClientAuthMode is the policy of requesting and verifying client certificates on the listener; ClientAuthRequire if empty
+	ClientAuthMode ClientAuthMode
+}
+
+// hostedServer is the HTTP server serving the routes of the application on one of its listeners
+type hostedServer struct {
+	setting ListenerSetting
+	server  *http.Server
+	https   bool
+}
+
+// getListenerSettings returns the settings of the default listener, built from the application address and the hosting customization, followed by the customized additional listeners
+func getListenerSettings(
+	address string,
+	session *session,
+) []ListenerSetting {
+	var defaultSetting = ListenerSetting{
+		Name:                defaultListenerName,
+		Address:             address,
+		Listener:            session.customization.Listener(),
+		ServerCert:          session.customization.ServerCert(),
+		CaCertPool:          session.customization.CaCertPool(),
+		CertificateProvider: session.customization.CertificateProvider(),
+		ClientAuthMode:      session.customization.ClientAuthMode(),
+	}
+	return append(
+		[]ListenerSetting{defaultSetting},
+		session.customization.Listeners()...,
+	)
+}
+
+func getListenerAddress(setting ListenerSetting) string {
+	if setting.Listener == nil {
+		return setting.Address
+	}
+	return setting.Listener.Addr().String()
+}
+
+// createTLSConfig creates the TLS config looking up the server certificate and CA cert pool from the certificate provider on each handshake; no certificate provider means the listener is not hosted with HTTPS
+func createTLSConfig(
+	session *session,
+	provider CertificateProvider,
+	clientAuthMode ClientAuthMode,
+) (*tls.Config, bool) {
+	var tlsConfig = &tls.Config{
+		// TLS 1.2 as minimum requirement
+		MinVersion: tls.VersionTLS12,
+	}
+	if provider == nil {
+		return tlsConfig, false
+	}
+	var binder, isBinder = provider.(sessionBinder)
+	if isBinder {
+		binder.bindSession(session)
+	}
+	tlsConfig.ClientAuth = getTLSClientAuth(
+		clientAuthMode,
+		false,
+	)
+	tlsConfig.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return provider.Certificate()
+	}
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return getClientConfig(
+			tlsConfig,
+			provider,
+			clientAuthMode,
+		)
+	}
+	return tlsConfig, true
+}
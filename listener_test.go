@@ -0,0 +1,156 @@
+package webserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type dummyListenerCustomization struct {
+	DefaultCustomization
+	listeners []ListenerSetting
+}
+
+func (customization *dummyListenerCustomization) Listeners() []ListenerSetting {
+	return customization.listeners
+}
+
+func TestGetListenerSettings(t *testing.T) {
+	// arrange
+	var dummyAddress = "some address"
+	var dummyAdditional = []ListenerSetting{
+		{Name: "internal", Address: "some internal address"},
+		{Name: "uds", Listener: &net.UnixListener{}},
+	}
+	var dummyCustomization = &dummyListenerCustomization{
+		listeners: dummyAdditional,
+	}
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: dummyCustomization,
+	}
+
+	// SUT + act
+	var result = getListenerSettings(
+		dummyAddress,
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, []ListenerSetting{
+		{Name: defaultListenerName, Address: dummyAddress},
+		dummyAdditional[0],
+		dummyAdditional[1],
+	}, result)
+}
+
+func TestGetListenerAddress_NoListener(t *testing.T) {
+	// arrange
+	var dummySetting = ListenerSetting{
+		Address: "some address",
+	}
+
+	// SUT + act
+	var result = getListenerAddress(
+		dummySetting,
+	)
+
+	// assert
+	assert.Equal(t, "some address", result)
+}
+
+func TestGetListenerAddress_WithListener(t *testing.T) {
+	// arrange
+	var dummyListener, listenError = net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, listenError)
+	defer dummyListener.Close()
+	var dummySetting = ListenerSetting{
+		Address:  "some address",
+		Listener: dummyListener,
+	}
+
+	// SUT + act
+	var result = getListenerAddress(
+		dummySetting,
+	)
+
+	// assert
+	assert.Equal(t, dummyListener.Addr().String(), result)
+}
+
+func TestCreateTLSConfig_NoProvider(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+
+	// SUT + act
+	var tlsConfig, https = createTLSConfig(
+		dummySession,
+		nil,
+		ClientAuthRequire,
+	)
+
+	// assert
+	assert.NotNil(t, tlsConfig)
+	assert.Empty(t, tlsConfig.Certificates)
+	assert.Nil(t, tlsConfig.GetCertificate)
+	assert.Nil(t, tlsConfig.GetConfigForClient)
+	assert.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth)
+	assert.Nil(t, tlsConfig.ClientCAs)
+	assert.Empty(t, tlsConfig.CipherSuites)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	assert.False(t, https)
+}
+
+func TestCreateTLSConfig_StaticProvider(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyServerCert = &tls.Certificate{}
+	var dummyCaCertPool = &x509.CertPool{}
+	var dummyProvider = &staticCertificateProvider{
+		serverCert: dummyServerCert,
+		caCertPool: dummyCaCertPool,
+	}
+
+	// SUT + act
+	var tlsConfig, https = createTLSConfig(
+		dummySession,
+		dummyProvider,
+		"",
+	)
+	var serverCert, certError = tlsConfig.GetCertificate(&tls.ClientHelloInfo{})
+	var clientConfig, configError = tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+
+	// assert
+	assert.NotNil(t, tlsConfig)
+	assert.Empty(t, tlsConfig.Certificates)
+	assert.Equal(t, tls.RequireAnyClientCert, tlsConfig.ClientAuth)
+	assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	assert.True(t, https)
+	assert.Equal(t, dummyServerCert, serverCert)
+	assert.NoError(t, certError)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, clientConfig.ClientAuth)
+	assert.Equal(t, dummyCaCertPool, clientConfig.ClientCAs)
+	assert.NoError(t, configError)
+}
+
+func TestCreateTLSConfig_SessionBinder(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyProvider = &fileCertificateProvider{}
+
+	// SUT + act
+	var tlsConfig, https = createTLSConfig(
+		dummySession,
+		dummyProvider,
+		ClientAuthNone,
+	)
+
+	// assert
+	assert.True(t, https)
+	assert.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth)
+	assert.Equal(t, dummySession, dummyProvider.session)
+}
@@ -0,0 +1,176 @@
+package webserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// LogFilter holds the allowed log types and the minimum log level for logs to be sent to the logging backend; logs filtered out are skipped before their messages are formatted
+type LogFilter struct {
+	LogType  LogType
+	LogLevel LogLevel
+}
+
+// String returns the text representation of the log filter in the form of "<LogType>;<LogLevel>", e.g. "GeneralTracing|MethodLogic;Warn"
+func (filter LogFilter) String() string {
+	return filter.LogType.String() + ";" + filter.LogLevel.String()
+}
+
+// logFilterResponse is the JSON representation of the log filter used by LogFilterHandler
+type logFilterResponse struct {
+	LogType  string `json:"logType"`
+	LogLevel string `json:"logLevel"`
+}
+
+var (
+	defaultLogFilter = LogFilter{
+		LogType:  LogTypeFullLogging,
+		LogLevel: LogLevelDebug,
+	}
+	runtimeLogFilter atomic.Pointer[LogFilter]
+)
+
+// GetLogFilter returns the log filter currently applied to all sessions, which allows all logs unless changed by SetLogFilter
+func GetLogFilter() LogFilter {
+	var filter = runtimeLogFilter.Load()
+	if filter == nil {
+		return defaultLogFilter
+	}
+	return *filter
+}
+
+// SetLogFilter changes the log filter applied to all sessions at runtime, except for sessions with their own log filter given by the request header customized through LogFilterHeader
+func SetLogFilter(filter LogFilter) {
+	runtimeLogFilter.Store(
+		&filter,
+	)
+}
+
+// parseLogType parses the "|" separated names of log types, failing on any unknown name instead of ignoring it as NewLogType does
+func parseLogType(value string) (LogType, error) {
+	var combinedLogType LogType
+	for _, name := range strings.Split(value, "|") {
+		var logType, found = logTypeNameMapping[name]
+		if !found {
+			return 0, fmt.Errorf("unknown log type [%v]", name)
+		}
+		combinedLogType = combinedLogType | logType
+	}
+	return combinedLogType, nil
+}
+
+// parseLogLevel parses the name of a log level, failing on an unknown name instead of falling back to Debug as NewLogLevel does
+func parseLogLevel(value string) (LogLevel, error) {
+	var logLevel, found = logLevelNameMapping[value]
+	if !found {
+		return 0, fmt.Errorf("unknown log level [%v]", value)
+	}
+	return logLevel, nil
+}
+
+// parseLogFilter parses the text representation of a log filter in the form of "<LogType>;<LogLevel>"; the parts left empty are taken from the base filter, while unknown names fail the parsing
+func parseLogFilter(value string, base LogFilter) (LogFilter, error) {
+	var logType, logLevel, _ = strings.Cut(
+		value,
+		";",
+	)
+	logType = strings.TrimSpace(logType)
+	if logType != "" {
+		var parsedLogType, typeError = parseLogType(logType)
+		if typeError != nil {
+			return base, typeError
+		}
+		base.LogType = parsedLogType
+	}
+	logLevel = strings.TrimSpace(logLevel)
+	if logLevel != "" {
+		var parsedLogLevel, levelError = parseLogLevel(logLevel)
+		if levelError != nil {
+			return base, levelError
+		}
+		base.LogLevel = parsedLogLevel
+	}
+	return base, nil
+}
+
+// getRequestLogFilter returns the log filter given by the request header customized through LogFilterHeader; returns nil if not customized, not present or invalid
+func getRequestLogFilter(customization Customization, httpRequest *http.Request) *LogFilter {
+	var headerName = customization.LogFilterHeader()
+	if headerName == "" {
+		return nil
+	}
+	var headerValue = httpRequest.Header.Get(headerName)
+	if headerValue == "" {
+		return nil
+	}
+	var filter, parseError = parseLogFilter(
+		headerValue,
+		GetLogFilter(),
+	)
+	if parseError != nil {
+		return nil
+	}
+	return &filter
+}
+
+// isLogAllowed checks whether the log of given type and level passes the log filter of the session, or the runtime log filter if the session has none
+func isLogAllowed(session *session, logType LogType, logLevel LogLevel) bool {
+	var filter = GetLogFilter()
+	if session.logFilter != nil {
+		filter = *session.logFilter
+	}
+	return logLevel >= filter.LogLevel &&
+		filter.LogType.HasFlag(logType)
+}
+
+func writeLogFilter(responseWriter http.ResponseWriter, filter LogFilter) {
+	var responseBody, _ = json.Marshal(
+		logFilterResponse{
+			LogType:  filter.LogType.String(),
+			LogLevel: filter.LogLevel.String(),
+		},
+	)
+	responseWriter.Header().Set("Content-Type", ContentTypeJSON)
+	responseWriter.WriteHeader(http.StatusOK)
+	responseWriter.Write(responseBody)
+}
+
+// LogFilterHandler returns an HTTP handler for inspecting and changing the runtime log filter, hosted by the admin server under /logfilter, or to be mounted on an admin route through InstrumentRouter; GET returns the current filter, while PUT or POST changes it by the "logType" and "logLevel" query strings, leaving the absent ones unchanged and rejecting unknown names with bad request
+func LogFilterHandler() http.HandlerFunc {
+	return func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+		switch httpRequest.Method {
+		case http.MethodGet:
+			writeLogFilter(
+				responseWriter,
+				GetLogFilter(),
+			)
+		case http.MethodPut, http.MethodPost:
+			var query = httpRequest.URL.Query()
+			var filter, parseError = parseLogFilter(
+				query.Get("logType")+";"+query.Get("logLevel"),
+				GetLogFilter(),
+			)
+			if parseError != nil {
+				http.Error(
+					responseWriter,
+					parseError.Error(),
+					http.StatusBadRequest,
+				)
+				return
+			}
+			SetLogFilter(
+				filter,
+			)
+			writeLogFilter(
+				responseWriter,
+				filter,
+			)
+		default:
+			responseWriter.Header().Set("Allow", "GET, PUT, POST")
+			responseWriter.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
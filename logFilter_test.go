@@ -0,0 +1,297 @@
+package webserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogFilterString(t *testing.T) {
+	// arrange
+	var dummyFilter = LogFilter{
+		LogType:  LogTypeEndpointEnter | LogTypeMethodLogic,
+		LogLevel: LogLevelWarn,
+	}
+
+	// SUT + act
+	var result = dummyFilter.String()
+
+	// assert
+	assert.Equal(t, "EndpointEnter|MethodLogic;Warn", result)
+}
+
+func TestGetLogFilter_Default(t *testing.T) {
+	// arrange
+	runtimeLogFilter.Store(nil)
+
+	// SUT + act
+	var result = GetLogFilter()
+
+	// assert
+	assert.Equal(t, LogFilter{LogType: LogTypeFullLogging, LogLevel: LogLevelDebug}, result)
+}
+
+func TestSetLogFilter(t *testing.T) {
+	// arrange
+	var dummyFilter = LogFilter{
+		LogType:  LogTypeGeneralTracing,
+		LogLevel: LogLevelError,
+	}
+	defer runtimeLogFilter.Store(nil)
+
+	// SUT + act
+	SetLogFilter(
+		dummyFilter,
+	)
+	var result = GetLogFilter()
+
+	// assert
+	assert.Equal(t, dummyFilter, result)
+}
+
+func TestParseLogType(t *testing.T) {
+	// SUT + act
+	var result1, err1 = parseLogType("MethodLogic|WebcallStart")
+	var result2, err2 = parseLogType("MethodLogic|Typo")
+
+	// assert
+	assert.Equal(t, LogTypeMethodLogic|LogTypeWebcallStart, result1)
+	assert.NoError(t, err1)
+	assert.Zero(t, result2)
+	assert.EqualError(t, err2, "unknown log type [Typo]")
+}
+
+func TestParseLogLevel(t *testing.T) {
+	// SUT + act
+	var result1, err1 = parseLogLevel("Warn")
+	var result2, err2 = parseLogLevel("Typo")
+
+	// assert
+	assert.Equal(t, LogLevelWarn, result1)
+	assert.NoError(t, err1)
+	assert.Zero(t, result2)
+	assert.EqualError(t, err2, "unknown log level [Typo]")
+}
+
+func TestParseLogFilter(t *testing.T) {
+	// arrange
+	var dummyBase = LogFilter{
+		LogType:  LogTypeGeneralTracing,
+		LogLevel: LogLevelError,
+	}
+
+	// SUT + act
+	var result1, err1 = parseLogFilter("", dummyBase)
+	var result2, err2 = parseLogFilter(" FullDebugging ", dummyBase)
+	var result3, err3 = parseLogFilter("; Warn", dummyBase)
+	var result4, err4 = parseLogFilter("MethodLogic|WebcallStart;Debug", dummyBase)
+	var result5, err5 = parseLogFilter("Typo;Debug", dummyBase)
+	var result6, err6 = parseLogFilter("MethodLogic;Typo", dummyBase)
+
+	// assert
+	assert.Equal(t, dummyBase, result1)
+	assert.NoError(t, err1)
+	assert.Equal(t, LogFilter{LogType: LogTypeFullDebugging, LogLevel: LogLevelError}, result2)
+	assert.NoError(t, err2)
+	assert.Equal(t, LogFilter{LogType: LogTypeGeneralTracing, LogLevel: LogLevelWarn}, result3)
+	assert.NoError(t, err3)
+	assert.Equal(t, LogFilter{LogType: LogTypeMethodLogic | LogTypeWebcallStart, LogLevel: LogLevelDebug}, result4)
+	assert.NoError(t, err4)
+	assert.Equal(t, dummyBase, result5)
+	assert.EqualError(t, err5, "unknown log type [Typo]")
+	assert.Equal(t, LogFilter{LogType: LogTypeMethodLogic, LogLevel: LogLevelError}, result6)
+	assert.EqualError(t, err6, "unknown log level [Typo]")
+}
+
+func TestGetRequestLogFilter_NoHeaderCustomized(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"X-Log-Filter": {"FullDebugging;Debug"}},
+	}
+
+	// SUT + act
+	var result = getRequestLogFilter(
+		dummyCustomization,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+type dummyLogFilterCustomization struct {
+	DefaultCustomization
+}
+
+func (customization *dummyLogFilterCustomization) LogFilterHeader() string {
+	return "X-Log-Filter"
+}
+
+func TestGetRequestLogFilter_NoHeaderPresent(t *testing.T) {
+	// arrange
+	var dummyCustomization = &dummyLogFilterCustomization{}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+
+	// SUT + act
+	var result = getRequestLogFilter(
+		dummyCustomization,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestGetRequestLogFilter_HeaderPresent(t *testing.T) {
+	// arrange
+	var dummyCustomization = &dummyLogFilterCustomization{}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"X-Log-Filter": {";Warn"}},
+	}
+	SetLogFilter(LogFilter{LogType: LogTypeGeneralTracing, LogLevel: LogLevelError})
+	defer runtimeLogFilter.Store(nil)
+
+	// SUT + act
+	var result = getRequestLogFilter(
+		dummyCustomization,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, &LogFilter{LogType: LogTypeGeneralTracing, LogLevel: LogLevelWarn}, result)
+}
+
+func TestGetRequestLogFilter_HeaderInvalid(t *testing.T) {
+	// arrange
+	var dummyCustomization = &dummyLogFilterCustomization{}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{"X-Log-Filter": {";Typo"}},
+	}
+
+	// SUT + act
+	var result = getRequestLogFilter(
+		dummyCustomization,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestIsLogAllowed_RuntimeFilter(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	SetLogFilter(LogFilter{LogType: LogTypeGeneralTracing, LogLevel: LogLevelWarn})
+	defer runtimeLogFilter.Store(nil)
+
+	// assert
+	assert.False(t, isLogAllowed(dummySession, LogTypeEndpointEnter, LogLevelInfo))
+	assert.True(t, isLogAllowed(dummySession, LogTypeMethodLogic, LogLevelWarn))
+	assert.False(t, isLogAllowed(dummySession, LogTypeMethodLogic, LogLevelInfo))
+	assert.False(t, isLogAllowed(dummySession, LogTypeEndpointRequest, LogLevelError))
+	assert.True(t, isLogAllowed(dummySession, LogTypeAppRoot, LogLevelError))
+}
+
+func TestIsLogAllowed_SessionFilter(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		logFilter: &LogFilter{LogType: LogTypeFullDebugging, LogLevel: LogLevelDebug},
+	}
+	SetLogFilter(LogFilter{LogType: LogTypeGeneralTracing, LogLevel: LogLevelWarn})
+	defer runtimeLogFilter.Store(nil)
+
+	// assert
+	assert.True(t, isLogAllowed(dummySession, LogTypeEndpointRequest, LogLevelInfo))
+	assert.True(t, isLogAllowed(dummySession, LogTypeMethodLogic, LogLevelDebug))
+	assert.False(t, isLogAllowed(dummySession, LogTypeEndpointEnter, LogLevelFatal))
+}
+
+func TestLogFilterHandler_Get(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyHTTPRequest = httptest.NewRequest(http.MethodGet, "/log-filter", nil)
+	SetLogFilter(LogFilter{LogType: LogTypeMethodLogic, LogLevel: LogLevelWarn})
+	defer runtimeLogFilter.Store(nil)
+
+	// SUT
+	var sut = LogFilterHandler()
+
+	// act
+	sut(
+		dummyRecorder,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.Equal(t, ContentTypeJSON, dummyRecorder.Header().Get("Content-Type"))
+	assert.Equal(t, `{"logType":"MethodLogic","logLevel":"Warn"}`, dummyRecorder.Body.String())
+}
+
+func TestLogFilterHandler_Put(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyHTTPRequest = httptest.NewRequest(http.MethodPut, "/log-filter?logLevel=Error", nil)
+	SetLogFilter(LogFilter{LogType: LogTypeMethodLogic, LogLevel: LogLevelWarn})
+	defer runtimeLogFilter.Store(nil)
+
+	// SUT
+	var sut = LogFilterHandler()
+
+	// act
+	sut(
+		dummyRecorder,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusOK, dummyRecorder.Code)
+	assert.Equal(t, `{"logType":"MethodLogic","logLevel":"Error"}`, dummyRecorder.Body.String())
+	assert.Equal(t, LogFilter{LogType: LogTypeMethodLogic, LogLevel: LogLevelError}, GetLogFilter())
+}
+
+func TestLogFilterHandler_PutInvalid(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyHTTPRequest = httptest.NewRequest(http.MethodPut, "/log-filter?logType=Typo&logLevel=Error", nil)
+	SetLogFilter(LogFilter{LogType: LogTypeMethodLogic, LogLevel: LogLevelWarn})
+	defer runtimeLogFilter.Store(nil)
+
+	// SUT
+	var sut = LogFilterHandler()
+
+	// act
+	sut(
+		dummyRecorder,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, dummyRecorder.Code)
+	assert.Equal(t, "unknown log type [Typo]\n", dummyRecorder.Body.String())
+	assert.Equal(t, LogFilter{LogType: LogTypeMethodLogic, LogLevel: LogLevelWarn}, GetLogFilter())
+}
+
+func TestLogFilterHandler_MethodNotAllowed(t *testing.T) {
+	// arrange
+	var dummyRecorder = httptest.NewRecorder()
+	var dummyHTTPRequest = httptest.NewRequest(http.MethodDelete, "/log-filter", nil)
+
+	// SUT
+	var sut = LogFilterHandler()
+
+	// act
+	sut(
+		dummyRecorder,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, http.StatusMethodNotAllowed, dummyRecorder.Code)
+	assert.Equal(t, "GET, PUT, POST", dummyRecorder.Header().Get("Allow"))
+}
@@ -0,0 +1,108 @@
+package webserver
+
+import (
+	"log/slog"
+	"time"
+)
+
+// slogLevelFatal is the log/slog level mapped from LogLevelFatal, which has no counterpart in log/slog
+const slogLevelFatal = slog.LevelError + 4
+
+// getSlogLevel maps the given log level to its log/slog counterpart
+func getSlogLevel(logLevel LogLevel) slog.Level {
+	switch logLevel {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	case LogLevelFatal:
+		return slogLevelFatal
+	}
+	return slog.LevelInfo
+}
+
+// getLogAttributes returns the typed fields of a log entry for the given session, followed by the attributes added through the session
+func getLogAttributes(
+	session *session,
+	logType LogType,
+	logLevel LogLevel,
+	category string,
+	subcategory string,
+) []slog.Attr {
+	var attributes = []slog.Attr{
+		slog.String("sessionID", session.id.String()),
+		slog.String("name", session.name),
+		slog.String("method", session.method),
+		slog.String("route", session.pattern),
+		slog.String("traceID", session.trace.traceID),
+		slog.String("spanID", session.trace.spanID),
+		slog.String("logType", logType.String()),
+		slog.String("logLevel", logLevel.String()),
+		slog.String("category", category),
+		slog.String("subcategory", subcategory),
+	}
+	if session.statusCode != 0 {
+		attributes = append(
+			attributes,
+			slog.Int("status", session.statusCode),
+		)
+	}
+	if !session.startTime.IsZero() {
+		attributes = append(
+			attributes,
+			slog.Duration("duration", time.Since(session.startTime)),
+		)
+	}
+	return append(
+		attributes,
+		session.logAttributes...,
+	)
+}
+
+// logStructured sends the log entry to the given log/slog handler with typed fields, skipping the message formatting if the handler is not enabled for the level
+func logStructured(
+	session *session,
+	handler slog.Handler,
+	logType LogType,
+	logLevel LogLevel,
+	category string,
+	subcategory string,
+	messageFormat string,
+	parameters ...any,
+) {
+	var requestContext = session.GetRequest().Context()
+	var level = getSlogLevel(
+		logLevel,
+	)
+	if !handler.Enabled(requestContext, level) {
+		return
+	}
+	var record = slog.NewRecord(
+		getTimeNowUTC(),
+		level,
+		formatLogDescription(
+			session,
+			logType,
+			category,
+			subcategory,
+			messageFormat,
+			parameters...,
+		),
+		0,
+	)
+	record.AddAttrs(
+		getLogAttributes(
+			session,
+			logType,
+			logLevel,
+			category,
+			subcategory,
+		)...,
+	)
+	handler.Handle(
+		requestContext,
+		record,
+	)
+}
@@ -0,0 +1,185 @@
+package webserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestGetSlogLevel(t *testing.T) {
+	// assert
+	assert.Equal(t, slog.LevelDebug, getSlogLevel(LogLevelDebug))
+	assert.Equal(t, slog.LevelInfo, getSlogLevel(LogLevelInfo))
+	assert.Equal(t, slog.LevelWarn, getSlogLevel(LogLevelWarn))
+	assert.Equal(t, slog.LevelError, getSlogLevel(LogLevelError))
+	assert.Equal(t, slogLevelFatal, getSlogLevel(LogLevelFatal))
+	assert.Equal(t, slog.LevelInfo, getSlogLevel(maxLogLevel))
+}
+
+func TestGetLogAttributes_AppRoot(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		id:      uuid.New(),
+		name:    "some name",
+		method:  "none",
+		pattern: "root",
+		trace: traceContext{
+			traceID: "some trace ID",
+			spanID:  "some span ID",
+		},
+	}
+
+	// SUT + act
+	var result = getLogAttributes(
+		dummySession,
+		LogTypeAppRoot,
+		LogLevelWarn,
+		"some category",
+		"some subcategory",
+	)
+
+	// assert
+	assert.Equal(t, []slog.Attr{
+		slog.String("sessionID", dummySession.id.String()),
+		slog.String("name", "some name"),
+		slog.String("method", "none"),
+		slog.String("route", "root"),
+		slog.String("traceID", "some trace ID"),
+		slog.String("spanID", "some span ID"),
+		slog.String("logType", "AppRoot"),
+		slog.String("logLevel", "Warn"),
+		slog.String("category", "some category"),
+		slog.String("subcategory", "some subcategory"),
+	}, result)
+}
+
+func TestGetLogAttributes_Endpoint(t *testing.T) {
+	// arrange
+	var dummyStatusCode = rand.IntN(500) + 100
+	var dummyAttribute = slog.String("some key", "some value")
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(1000))
+	var dummySession = &session{
+		id:            uuid.New(),
+		name:          "some name",
+		method:        "some method",
+		pattern:       "some pattern",
+		startTime:     dummyStartTime,
+		statusCode:    dummyStatusCode,
+		logAttributes: []slog.Attr{dummyAttribute},
+		trace: traceContext{
+			traceID: "some trace ID",
+			spanID:  "some span ID",
+		},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyDuration).Once()
+
+	// SUT + act
+	var result = getLogAttributes(
+		dummySession,
+		LogTypeEndpointExit,
+		LogLevelInfo,
+		"some category",
+		"some subcategory",
+	)
+
+	// assert
+	assert.Equal(t, []slog.Attr{
+		slog.String("sessionID", dummySession.id.String()),
+		slog.String("name", "some name"),
+		slog.String("method", "some method"),
+		slog.String("route", "some pattern"),
+		slog.String("traceID", "some trace ID"),
+		slog.String("spanID", "some span ID"),
+		slog.String("logType", "EndpointExit"),
+		slog.String("logLevel", "Info"),
+		slog.String("category", "some category"),
+		slog.String("subcategory", "some subcategory"),
+		slog.Int("status", dummyStatusCode),
+		slog.Duration("duration", dummyDuration),
+		dummyAttribute,
+	}, result)
+}
+
+func TestLogStructured_NotEnabled(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyBuffer = &bytes.Buffer{}
+	var dummyHandler = slog.NewJSONHandler(dummyBuffer, &slog.HandlerOptions{Level: slog.LevelWarn})
+
+	// SUT + act
+	logStructured(
+		dummySession,
+		dummyHandler,
+		LogTypeMethodLogic,
+		LogLevelInfo,
+		"some category",
+		"some subcategory",
+		"%v",
+		"some parameter",
+	)
+
+	// assert
+	assert.Zero(t, dummyBuffer.Len())
+}
+
+func TestLogStructured_Enabled(t *testing.T) {
+	// arrange
+	var dummyContext = context.WithValue(context.Background(), "some key", "some value")
+	var dummySession = &session{
+		request: (&http.Request{}).WithContext(dummyContext),
+	}
+	var dummyBuffer = &bytes.Buffer{}
+	var dummyHandler = slog.NewJSONHandler(dummyBuffer, nil)
+	var dummyTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var dummyAttributes = []slog.Attr{
+		slog.String("some key", "some value"),
+		slog.Int("some number", 123),
+	}
+	var result map[string]any
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyTime).Once()
+	m.Mock(formatLogDescription).Expects(dummySession, LogTypeMethodLogic, "some category", "some subcategory", "%v-%v", "some parameter", 123).Returns("some description").Once()
+	m.Mock(getLogAttributes).Expects(dummySession, LogTypeMethodLogic, LogLevelError, "some category", "some subcategory").Returns(dummyAttributes).Once()
+
+	// SUT + act
+	logStructured(
+		dummySession,
+		dummyHandler,
+		LogTypeMethodLogic,
+		LogLevelError,
+		"some category",
+		"some subcategory",
+		"%v-%v",
+		"some parameter",
+		123,
+	)
+
+	// assert
+	assert.NoError(t, json.Unmarshal(dummyBuffer.Bytes(), &result))
+	assert.Equal(t, map[string]any{
+		"time":        "2024-01-02T03:04:05Z",
+		"level":       "ERROR",
+		"msg":         "some description",
+		"some key":    "some value",
+		"some number": float64(123),
+	}, result)
+}
@@ -0,0 +1,51 @@
+package webserver
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"unicode/utf8"
+)
+
+// isLogBodySampled decides whether request and response bodies are logged for the given session, according to the sampling rate customized for its route
+func isLogBodySampled(session *session) bool {
+	var sampleRate = session.customization.LogBodySampleRate(
+		session,
+	)
+	if sampleRate >= 1 {
+		return true
+	}
+	if sampleRate <= 0 {
+		return false
+	}
+	return rand.Float64() < sampleRate
+}
+
+// isBodyLog checks whether the given log entry carries the content of an endpoint or webcall request or response body
+func isBodyLog(logType LogType, category string, subcategory string) bool {
+	switch logType {
+	case LogTypeEndpointRequest, LogTypeWebcallResponse:
+		return category == "Body" && subcategory == "Content"
+	case LogTypeWebcallRequest:
+		return category == "Payload" && subcategory == "Content"
+	case LogTypeEndpointResponse:
+		return category != "None"
+	}
+	return false
+}
+
+// truncateLogDescription truncates the given description to the limit in bytes without breaking UTF-8 characters, marking the number of bytes truncated
+func truncateLogDescription(description string, limit int) string {
+	if limit <= 0 || len(description) <= limit {
+		return description
+	}
+	var length = limit
+	for length > 0 && !utf8.RuneStart(description[length]) {
+		length--
+	}
+	return fmt.Sprintf(
+		"%s...[truncated %d of %d bytes]",
+		description[:length],
+		len(description)-length,
+		len(description),
+	)
+}
@@ -0,0 +1,97 @@
+package webserver
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type dummySampleRateCustomization struct {
+	DefaultCustomization
+	sampleRate float64
+}
+
+func (customization *dummySampleRateCustomization) LogBodySampleRate(session Session) float64 {
+	return customization.sampleRate
+}
+
+func TestIsLogBodySampled_Always(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummySampleRateCustomization{sampleRate: 1},
+	}
+
+	// SUT + act
+	var result = isLogBodySampled(
+		dummySession,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestIsLogBodySampled_Never(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummySampleRateCustomization{sampleRate: 0},
+	}
+
+	// SUT + act
+	var result = isLogBodySampled(
+		dummySession,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLogBodySampled_Sampling(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummySampleRateCustomization{sampleRate: 0.5},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(rand.Float64).Expects().Returns(0.3).Once()
+	m.Mock(rand.Float64).Expects().Returns(0.7).Once()
+
+	// SUT + act
+	var result1 = isLogBodySampled(
+		dummySession,
+	)
+	var result2 = isLogBodySampled(
+		dummySession,
+	)
+
+	// assert
+	assert.True(t, result1)
+	assert.False(t, result2)
+}
+
+func TestIsBodyLog(t *testing.T) {
+	// assert
+	assert.True(t, isBodyLog(LogTypeEndpointRequest, "Body", "Content"))
+	assert.False(t, isBodyLog(LogTypeEndpointRequest, "Body", "UnmarshalError"))
+	assert.False(t, isBodyLog(LogTypeEndpointRequest, "Header", "Content"))
+	assert.True(t, isBodyLog(LogTypeWebcallResponse, "Body", "Content"))
+	assert.False(t, isBodyLog(LogTypeWebcallResponse, "Body", "Streamed"))
+	assert.True(t, isBodyLog(LogTypeWebcallRequest, "Payload", "Content"))
+	assert.False(t, isBodyLog(LogTypeWebcallRequest, "Payload", "Stream"))
+	assert.True(t, isBodyLog(LogTypeEndpointResponse, "OK", "200"))
+	assert.False(t, isBodyLog(LogTypeEndpointResponse, "None", "-1"))
+	assert.False(t, isBodyLog(LogTypeMethodLogic, "Body", "Content"))
+}
+
+func TestTruncateLogDescription(t *testing.T) {
+	// assert
+	assert.Equal(t, "some description", truncateLogDescription("some description", 0))
+	assert.Equal(t, "some description", truncateLogDescription("some description", 16))
+	assert.Equal(t, "some...[truncated 12 of 16 bytes]", truncateLogDescription("some description", 4))
+	assert.Equal(t, "a...[truncated 4 of 5 bytes]", truncateLogDescription("a你b", 3))
+	assert.Equal(t, "...[truncated 3 of 3 bytes]", truncateLogDescription("你", 1))
+}
@@ -2,6 +2,45 @@ package webserver
 
 import "fmt"
 
+// formatLogDescription formats the description of a log entry, with bodies of sessions not sampled logged by sizes only, sensitive data redacted and the result truncated according to customization
+func formatLogDescription(
+	session *session,
+	logType LogType,
+	category string,
+	subcategory string,
+	messageFormat string,
+	parameters ...any,
+) string {
+	var description = fmt.Sprintf(
+		messageFormat,
+		parameters...,
+	)
+	if session.logBodyUnsampled &&
+		isBodyLog(
+			logType,
+			category,
+			subcategory,
+		) {
+		return fmt.Sprintf(
+			"[not sampled: %d bytes]",
+			len(description),
+		)
+	}
+	description = redactLogEntry(
+		session,
+		logType,
+		category,
+		subcategory,
+		description,
+	)
+	return truncateLogDescription(
+		description,
+		session.customization.LogTruncationLimit(
+			logType,
+		),
+	)
+}
+
 func prepareLogging(
 	session *session,
 	logType LogType,
@@ -11,7 +50,26 @@ func prepareLogging(
 	messageFormat string,
 	parameters ...any,
 ) {
-	if session == nil {
+	if session == nil ||
+		!isLogAllowed(
+			session,
+			logType,
+			logLevel,
+		) {
+		return
+	}
+	var handler = session.customization.LogHandler()
+	if !isInterfaceValueNil(handler) {
+		logStructured(
+			session,
+			handler,
+			logType,
+			logLevel,
+			category,
+			subcategory,
+			messageFormat,
+			parameters...,
+		)
 		return
 	}
 	session.customization.Log(
@@ -20,7 +78,11 @@ func prepareLogging(
 		logLevel,
 		category,
 		subcategory,
-		fmt.Sprintf(
+		formatLogDescription(
+			session,
+			logType,
+			category,
+			subcategory,
 			messageFormat,
 			parameters...,
 		),
@@ -3,13 +3,85 @@ package webserver
 import (
 	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"math/rand/v2"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/gomocker/v2"
 )
 
+type dummyLogLimitCustomization struct {
+	DefaultCustomization
+	limit int
+}
+
+func (customization *dummyLogLimitCustomization) LogTruncationLimit(logType LogType) int {
+	return customization.limit
+}
+
+func TestFormatLogDescription_Unsampled(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		logBodyUnsampled: true,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isBodyLog).Expects(LogTypeEndpointRequest, "Body", "Content").Returns(true).Once()
+
+	// SUT + act
+	var result = formatLogDescription(
+		dummySession,
+		LogTypeEndpointRequest,
+		"Body",
+		"Content",
+		"%s",
+		"some body",
+	)
+
+	// assert
+	assert.Equal(t, "[not sampled: 9 bytes]", result)
+}
+
+func TestFormatLogDescription_Sampled(t *testing.T) {
+	// arrange
+	var dummyCustomization = &dummyLogLimitCustomization{
+		limit: rand.IntN(100),
+	}
+	var dummySession = &session{
+		customization:    dummyCustomization,
+		logBodyUnsampled: true,
+	}
+	var dummyRedacted = "some redacted description"
+	var dummyTruncated = "some truncated description"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isBodyLog).Expects(LogTypeEndpointRequest, "Header", "Content").Returns(false).Once()
+	m.Mock(redactLogEntry).Expects(dummySession, LogTypeEndpointRequest, "Header", "Content", "some header").Returns(dummyRedacted).Once()
+	m.Mock(truncateLogDescription).Expects(dummyRedacted, dummyCustomization.limit).Returns(dummyTruncated).Once()
+
+	// SUT + act
+	var result = formatLogDescription(
+		dummySession,
+		LogTypeEndpointRequest,
+		"Header",
+		"Content",
+		"%s",
+		"some header",
+	)
+
+	// assert
+	assert.Equal(t, dummyTruncated, result)
+}
+
 func TestPrepareLoggingFunc_NilSession(t *testing.T) {
 	// arrange
 	var dummySession *session
@@ -73,6 +145,68 @@ func TestPrepareLoggingFunc_HappyPath(t *testing.T) {
 	)
 }
 
+func TestPrepareLoggingFunc_FilteredOut(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyLogType = LogType(rand.IntN(100))
+	var dummyLogLevel = LogLevel(rand.IntN(100))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isLogAllowed).Expects(dummySession, dummyLogType, dummyLogLevel).Returns(false).Once()
+
+	// SUT + act
+	prepareLogging(
+		dummySession,
+		dummyLogType,
+		dummyLogLevel,
+		"some category",
+		"some subcategory",
+		"some message format",
+	)
+}
+
+func TestPrepareLoggingFunc_LogHandler(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyHandler = slog.NewTextHandler(io.Discard, nil)
+	var dummyLogType = LogType(rand.IntN(100))
+	var dummyLogLevel = LogLevel(rand.IntN(100))
+	var dummyCategory = "some category"
+	var dummySubcategory = "some subcategory"
+	var dummyMessageFormat = "%v %v"
+	var dummyParameter1 = "some parameter 1"
+	var dummyParameter2 = rand.Int()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).LogHandler).Expects(dummyCustomization).Returns(dummyHandler).Once()
+	m.Mock(logStructured).Expects(dummySession, dummyHandler, dummyLogType, dummyLogLevel,
+		dummyCategory, dummySubcategory, dummyMessageFormat, dummyParameter1, dummyParameter2).Returns().Once()
+
+	// SUT + act
+	prepareLogging(
+		dummySession,
+		dummyLogType,
+		dummyLogLevel,
+		dummyCategory,
+		dummySubcategory,
+		dummyMessageFormat,
+		dummyParameter1,
+		dummyParameter2,
+	)
+}
+
 func TestLogAppRoot(t *testing.T) {
 	// arrange
 	var dummySession = &session{
@@ -0,0 +1,191 @@
+package webserver
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsNamespace is the prefix of all Prometheus metrics exposed by the web server
+const metricsNamespace = "webserver"
+
+// webcallStatusError is the status label of webcalls receiving no response at all, e.g. upon connectivity errors, cancellation or open circuit
+const webcallStatusError = "error"
+
+var (
+	requestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests handled by sessions, by method, route pattern and status code",
+		},
+		[]string{"method", "route", "status"},
+	)
+	requestDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests handled by sessions, by method and route pattern",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method", "route"},
+	)
+	requestsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Number of HTTP requests currently being handled by sessions, by method and route pattern",
+		},
+		[]string{"method", "route"},
+	)
+	webcallsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "webcall_requests_total",
+			Help:      "Number of webcalls processed by sessions, by method, host and status code, or error if no response is received",
+		},
+		[]string{"method", "host", "status"},
+	)
+	webcallDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "webcall_request_duration_seconds",
+			Help:      "Duration of webcalls processed by sessions including retries, by method and host",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"method", "host"},
+	)
+	panicsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "panics_total",
+			Help:      "Number of panics recovered from sessions, by method and route pattern",
+		},
+		[]string{"method", "route"},
+	)
+	appErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "app_errors_total",
+			Help:      "Number of AppErrors responded by sessions, by method, route pattern and error code",
+		},
+		[]string{"method", "route", "code"},
+	)
+	metricsRegistry = newMetricsRegistry()
+)
+
+func newMetricsRegistry() *prometheus.Registry {
+	var registry = prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestDurationSeconds,
+		requestsInFlight,
+		webcallsTotal,
+		webcallDurationSeconds,
+		panicsTotal,
+		appErrorsTotal,
+	)
+	return registry
+}
+
+// beginSessionMetrics counts the session as in flight under its route pattern
+func beginSessionMetrics(session *session) {
+	requestsInFlight.WithLabelValues(
+		session.request.Method,
+		session.pattern,
+	).Inc()
+}
+
+// finishSessionMetrics counts the session as completed with its response status code and records its duration
+func finishSessionMetrics(session *session, duration time.Duration) {
+	requestsInFlight.WithLabelValues(
+		session.request.Method,
+		session.pattern,
+	).Dec()
+	requestsTotal.WithLabelValues(
+		session.request.Method,
+		session.pattern,
+		strconv.Itoa(session.statusCode),
+	).Inc()
+	requestDurationSeconds.WithLabelValues(
+		session.request.Method,
+		session.pattern,
+	).Observe(
+		duration.Seconds(),
+	)
+}
+
+// countPanic counts the panic recovered from the session
+func countPanic(session *session) {
+	panicsTotal.WithLabelValues(
+		session.request.Method,
+		session.pattern,
+	).Inc()
+}
+
+// countAppError counts the response error of the session by its error code if it is an AppError
+func countAppError(session *session, responseError error) {
+	var appError AppError
+	if !errors.As(responseError, &appError) {
+		return
+	}
+	appErrorsTotal.WithLabelValues(
+		session.request.Method,
+		session.pattern,
+		appError.ErrorCode(),
+	).Inc()
+}
+
+// recordWebcallMetrics counts the webcall with its response status code, or as error if no response is received, and records its duration under its host
+func recordWebcallMetrics(webRequest *webRequest, duration time.Duration, statusCode int) {
+	var host = getServerAddress(
+		webRequest.url,
+	)
+	var status = webcallStatusError
+	if webRequest.responseReceived {
+		status = strconv.Itoa(statusCode)
+	}
+	webcallsTotal.WithLabelValues(
+		webRequest.method,
+		host,
+		status,
+	).Inc()
+	webcallDurationSeconds.WithLabelValues(
+		webRequest.method,
+		host,
+	).Observe(
+		duration.Seconds(),
+	)
+}
+
+// registerMetrics registers the Prometheus metrics endpoint in text exposition format on the customized path, if any
+func registerMetrics(
+	session *session,
+	router chi.Router,
+) {
+	var metricsPath = session.customization.MetricsPath()
+	if metricsPath == "" {
+		logAppRoot(
+			session,
+			LogLevelInfo,
+			"metrics",
+			"registerMetrics",
+			"customization.MetricsPath function empty: no metrics endpoint registered!",
+		)
+		return
+	}
+	router.Handle(
+		metricsPath,
+		promhttp.HandlerFor(
+			metricsRegistry,
+			promhttp.HandlerOpts{},
+		),
+	)
+}
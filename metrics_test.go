@@ -0,0 +1,218 @@
+package webserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type dummyMetricsCustomization struct {
+	DefaultCustomization
+	metricsPath string
+}
+
+func (customization *dummyMetricsCustomization) MetricsPath() string {
+	return customization.metricsPath
+}
+
+func TestNewMetricsRegistry(t *testing.T) {
+	// SUT + act
+	var result = newMetricsRegistry()
+
+	// assert
+	var families, err = result.Gather()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, families)
+}
+
+func TestBeginSessionMetrics(t *testing.T) {
+	// arrange
+	requestsInFlight.Reset()
+	var dummySession = &session{
+		pattern: "/items/{id}",
+		request: &http.Request{Method: http.MethodGet},
+	}
+
+	// SUT + act
+	beginSessionMetrics(
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(requestsInFlight.WithLabelValues(http.MethodGet, "/items/{id}")))
+}
+
+func TestFinishSessionMetrics(t *testing.T) {
+	// arrange
+	requestsInFlight.Reset()
+	requestsTotal.Reset()
+	requestDurationSeconds.Reset()
+	var dummySession = &session{
+		pattern:    "/items/{id}",
+		request:    &http.Request{Method: http.MethodGet},
+		statusCode: http.StatusNotFound,
+	}
+	beginSessionMetrics(dummySession)
+
+	// SUT + act
+	finishSessionMetrics(
+		dummySession,
+		time.Second,
+	)
+
+	// assert
+	assert.Equal(t, 0.0, testutil.ToFloat64(requestsInFlight.WithLabelValues(http.MethodGet, "/items/{id}")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(requestsTotal.WithLabelValues(http.MethodGet, "/items/{id}", "404")))
+	assert.Equal(t, 1, testutil.CollectAndCount(requestDurationSeconds))
+}
+
+func TestCountPanic(t *testing.T) {
+	// arrange
+	panicsTotal.Reset()
+	var dummySession = &session{
+		pattern: "/items",
+		request: &http.Request{Method: http.MethodPost},
+	}
+
+	// SUT + act
+	countPanic(
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(panicsTotal.WithLabelValues(http.MethodPost, "/items")))
+}
+
+func TestCountAppError_NotAppError(t *testing.T) {
+	// arrange
+	appErrorsTotal.Reset()
+	var dummySession = &session{
+		pattern: "/items",
+		request: &http.Request{Method: http.MethodPost},
+	}
+
+	// SUT + act
+	countAppError(
+		dummySession,
+		errors.New("some error"),
+	)
+
+	// assert
+	assert.Zero(t, testutil.CollectAndCount(appErrorsTotal))
+}
+
+func TestCountAppError_AppError(t *testing.T) {
+	// arrange
+	appErrorsTotal.Reset()
+	var dummySession = &session{
+		pattern: "/items",
+		request: &http.Request{Method: http.MethodPost},
+	}
+
+	// SUT + act
+	countAppError(
+		dummySession,
+		GetBadRequest("some error message"),
+	)
+
+	// assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(appErrorsTotal.WithLabelValues(http.MethodPost, "/items", "BadRequest")))
+}
+
+func TestRecordWebcallMetrics_NoResponse(t *testing.T) {
+	// arrange
+	webcallsTotal.Reset()
+	webcallDurationSeconds.Reset()
+	var dummyWebRequest = &webRequest{
+		method: http.MethodGet,
+		url:    "https://localhost:8443/items",
+	}
+
+	// SUT + act
+	recordWebcallMetrics(
+		dummyWebRequest,
+		time.Second,
+		http.StatusInternalServerError,
+	)
+
+	// assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(webcallsTotal.WithLabelValues(http.MethodGet, "localhost", "error")))
+	assert.Equal(t, 1, testutil.CollectAndCount(webcallsTotal))
+	assert.Equal(t, 1, testutil.CollectAndCount(webcallDurationSeconds))
+}
+
+func TestRecordWebcallMetrics_Response(t *testing.T) {
+	// arrange
+	webcallsTotal.Reset()
+	webcallDurationSeconds.Reset()
+	var dummyWebRequest = &webRequest{
+		method:           http.MethodGet,
+		url:              "https://localhost:8443/items",
+		responseReceived: true,
+	}
+
+	// SUT + act
+	recordWebcallMetrics(
+		dummyWebRequest,
+		time.Second,
+		http.StatusOK,
+	)
+
+	// assert
+	assert.Equal(t, 1.0, testutil.ToFloat64(webcallsTotal.WithLabelValues(http.MethodGet, "localhost", "200")))
+	assert.Equal(t, 1, testutil.CollectAndCount(webcallDurationSeconds))
+}
+
+func TestRegisterMetrics_NoPath(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummyMetricsCustomization{},
+	}
+	var dummyRouter = chi.NewRouter()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "metrics", "registerMetrics",
+		"customization.MetricsPath function empty: no metrics endpoint registered!").Returns().Once()
+
+	// SUT + act
+	registerMetrics(
+		dummySession,
+		dummyRouter,
+	)
+
+	// assert
+	assert.Empty(t, dummyRouter.Routes())
+}
+
+func TestRegisterMetrics_WithPath(t *testing.T) {
+	// arrange
+	requestsTotal.Reset()
+	requestsTotal.WithLabelValues(http.MethodGet, "/items", "200").Inc()
+	var dummySession = &session{
+		customization: &dummyMetricsCustomization{metricsPath: "/metrics"},
+	}
+	var dummyRouter = chi.NewRouter()
+	var recorder = httptest.NewRecorder()
+
+	// SUT + act
+	registerMetrics(
+		dummySession,
+		dummyRouter,
+	)
+	dummyRouter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// assert
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, recorder.Body.String(), `webserver_http_requests_total{method="GET",route="/items",status="200"} 1`)
+}
@@ -8,6 +8,9 @@ func handlePanic(
 	if recoverResult == nil {
 		return
 	}
+	countPanic(
+		session,
+	)
 	var responseObject, responseError = session.customization.RecoverPanic(
 		session,
 		recoverResult,
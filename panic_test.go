@@ -38,6 +38,7 @@ func TestHandlePanic_HappyPath(t *testing.T) {
 	// expect
 	m.Mock((*DefaultCustomization).RecoverPanic).Expects(dummyCustomization, dummySession, dummyRecoverResult).
 		Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(countPanic).Expects(dummySession).Returns().Once()
 	m.Mock(writeResponse).Expects(dummySession, dummyResponseObject, dummyResponseError).Returns().Once()
 
 	// SUT + act
@@ -0,0 +1,195 @@
+package webserver
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// defaultRedactionMask is the replacement of redacted contents used when RedactionSetting.Mask is not set
+const defaultRedactionMask = "[REDACTED]"
+
+// RedactionSetting holds the redaction configuration applied to EndpointRequest, EndpointResponse, WebcallRequest and WebcallResponse logs
+type RedactionSetting struct {
+	// HeaderNames are the names of headers whose values are redacted, matched case-insensitively, e.g. Authorization or Cookie
+	HeaderNames []string
+	// FieldPaths are the dot-separated paths of JSON fields whose values are redacted, matched from the root of JSON contents, where arrays are walked through transparently and "*" matches any field name, e.g. "password" or "users.*.ssn"
+	FieldPaths []string
+	// Patterns are the regular expressions whose matches are redacted from any log content, e.g. credit card or email patterns
+	Patterns []*regexp.Regexp
+	// Mask is the replacement of redacted contents; if not set, defaults to "[REDACTED]"
+	Mask string
+}
+
+func getRedactionMask(setting *RedactionSetting) string {
+	if setting.Mask == "" {
+		return defaultRedactionMask
+	}
+	return setting.Mask
+}
+
+func isRedactedHeader(setting *RedactionSetting, name string) bool {
+	for _, headerName := range setting.HeaderNames {
+		if strings.EqualFold(headerName, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRedactedFieldPath(setting *RedactionSetting, path []string) bool {
+	for _, fieldPath := range setting.FieldPaths {
+		var segments = strings.Split(fieldPath, ".")
+		if len(segments) != len(path) {
+			continue
+		}
+		var isMatch = true
+		for index, segment := range segments {
+			if segment != "*" && segment != path[index] {
+				isMatch = false
+				break
+			}
+		}
+		if isMatch {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSONFields walks through the given JSON content and replaces the values of redacted field paths with the mask, returning whether anything is redacted
+func redactJSONFields(setting *RedactionSetting, content any, path []string) (any, bool) {
+	switch typedContent := content.(type) {
+	case map[string]any:
+		var isRedacted = false
+		for key, value := range typedContent {
+			var fieldPath = append(path[:len(path):len(path)], key)
+			if isRedactedFieldPath(setting, fieldPath) {
+				typedContent[key] = getRedactionMask(setting)
+				isRedacted = true
+				continue
+			}
+			var redactedValue, isValueRedacted = redactJSONFields(
+				setting,
+				value,
+				fieldPath,
+			)
+			typedContent[key] = redactedValue
+			isRedacted = isRedacted || isValueRedacted
+		}
+		return typedContent, isRedacted
+	case []any:
+		var isRedacted = false
+		for index, value := range typedContent {
+			var redactedValue, isValueRedacted = redactJSONFields(
+				setting,
+				value,
+				path,
+			)
+			typedContent[index] = redactedValue
+			isRedacted = isRedacted || isValueRedacted
+		}
+		return typedContent, isRedacted
+	}
+	return content, false
+}
+
+// redactHeaders replaces the values of redacted headers in the given JSON representation of headers with the mask, returning whether anything is redacted
+func redactHeaders(setting *RedactionSetting, content any) (any, bool) {
+	var headers, isObject = content.(map[string]any)
+	if !isObject {
+		return content, false
+	}
+	var isRedacted = false
+	for name := range headers {
+		if isRedactedHeader(setting, name) {
+			headers[name] = []string{getRedactionMask(setting)}
+			isRedacted = true
+		}
+	}
+	return headers, isRedacted
+}
+
+// redactJSONContent redacts the given description if it is JSON content, either as headers or as payloads depending on the log category; returns the description unchanged if it is not JSON or nothing is redacted
+func redactJSONContent(setting *RedactionSetting, category string, description string) string {
+	var decoder = json.NewDecoder(
+		strings.NewReader(description),
+	)
+	decoder.UseNumber()
+	var content any
+	if decoder.Decode(&content) != nil ||
+		decoder.More() {
+		return description
+	}
+	var isRedacted bool
+	if category == "Header" {
+		content, isRedacted = redactHeaders(
+			setting,
+			content,
+		)
+	} else {
+		content, isRedacted = redactJSONFields(
+			setting,
+			content,
+			nil,
+		)
+	}
+	if !isRedacted {
+		return description
+	}
+	var buffer = &bytes.Buffer{}
+	var encoder = json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(content)
+	return strings.TrimRight(buffer.String(), "\n")
+}
+
+// redactLogDescription applies the redaction setting to the description of a log entry
+func redactLogDescription(setting *RedactionSetting, category string, subcategory string, description string) string {
+	if category == "Header" &&
+		isRedactedHeader(setting, subcategory) {
+		return getRedactionMask(setting)
+	}
+	description = redactJSONContent(
+		setting,
+		category,
+		description,
+	)
+	for _, pattern := range setting.Patterns {
+		if pattern == nil {
+			continue
+		}
+		description = pattern.ReplaceAllLiteralString(
+			description,
+			getRedactionMask(setting),
+		)
+	}
+	return description
+}
+
+// redactLogEntry redacts sensitive data from the description of EndpointRequest, EndpointResponse, WebcallRequest and WebcallResponse logs according to customization
+func redactLogEntry(
+	session *session,
+	logType LogType,
+	category string,
+	subcategory string,
+	description string,
+) string {
+	if logType != LogTypeEndpointRequest &&
+		logType != LogTypeEndpointResponse &&
+		logType != LogTypeWebcallRequest &&
+		logType != LogTypeWebcallResponse {
+		return description
+	}
+	var setting = session.customization.LogRedaction()
+	if setting == nil {
+		return description
+	}
+	return redactLogDescription(
+		setting,
+		category,
+		subcategory,
+		description,
+	)
+}
@@ -0,0 +1,231 @@
+package webserver
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type dummyRedactionCustomization struct {
+	DefaultCustomization
+	setting *RedactionSetting
+}
+
+func (customization *dummyRedactionCustomization) LogRedaction() *RedactionSetting {
+	return customization.setting
+}
+
+func TestGetRedactionMask(t *testing.T) {
+	// assert
+	assert.Equal(t, defaultRedactionMask, getRedactionMask(&RedactionSetting{}))
+	assert.Equal(t, "***", getRedactionMask(&RedactionSetting{Mask: "***"}))
+}
+
+func TestIsRedactedHeader(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		HeaderNames: []string{"Authorization", "x-api-key"},
+	}
+
+	// assert
+	assert.True(t, isRedactedHeader(dummySetting, "authorization"))
+	assert.True(t, isRedactedHeader(dummySetting, "X-Api-Key"))
+	assert.False(t, isRedactedHeader(dummySetting, "Content-Type"))
+}
+
+func TestIsRedactedFieldPath(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		FieldPaths: []string{"password", "users.*.ssn"},
+	}
+
+	// assert
+	assert.True(t, isRedactedFieldPath(dummySetting, []string{"password"}))
+	assert.True(t, isRedactedFieldPath(dummySetting, []string{"users", "foo", "ssn"}))
+	assert.False(t, isRedactedFieldPath(dummySetting, []string{"users", "foo", "name"}))
+	assert.False(t, isRedactedFieldPath(dummySetting, []string{"user", "password"}))
+}
+
+func TestRedactJSONContent_NotJSON(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		FieldPaths: []string{"password"},
+	}
+
+	// SUT + act
+	var result1 = redactJSONContent(dummySetting, "Body", "some plain text")
+	var result2 = redactJSONContent(dummySetting, "Body", `{"password":"a"} {"password":"b"}`)
+
+	// assert
+	assert.Equal(t, "some plain text", result1)
+	assert.Equal(t, `{"password":"a"} {"password":"b"}`, result2)
+}
+
+func TestRedactJSONContent_NothingRedacted(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		FieldPaths:  []string{"password"},
+		HeaderNames: []string{"Authorization"},
+	}
+	var dummyDescription = `{ "name": "foo", "amount": 1.50 }`
+
+	// SUT + act
+	var result1 = redactJSONContent(dummySetting, "Body", dummyDescription)
+	var result2 = redactJSONContent(dummySetting, "Header", dummyDescription)
+	var result3 = redactJSONContent(dummySetting, "Header", `["foo"]`)
+
+	// assert
+	assert.Equal(t, dummyDescription, result1)
+	assert.Equal(t, dummyDescription, result2)
+	assert.Equal(t, `["foo"]`, result3)
+}
+
+func TestRedactJSONContent_Fields(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		FieldPaths: []string{"password", "users.*.ssn", "token"},
+	}
+	var dummyDescription = `{"password":"secret","amount":1.50,"users":[{"profile":{"ssn":"123","name":"foo"}},{"profile":{"ssn":"456"}}],"note":"<b>"}`
+
+	// SUT + act
+	var result = redactJSONContent(
+		dummySetting,
+		"Body",
+		dummyDescription,
+	)
+
+	// assert
+	assert.Equal(t, `{"amount":1.50,"note":"<b>","password":"[REDACTED]","users":[{"profile":{"name":"foo","ssn":"[REDACTED]"}},{"profile":{"ssn":"[REDACTED]"}}]}`, result)
+}
+
+func TestRedactJSONContent_Headers(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		HeaderNames: []string{"authorization"},
+		Mask:        "***",
+	}
+	var dummyDescription = `{"Authorization":["Bearer abc"],"Content-Type":["application/json"]}`
+
+	// SUT + act
+	var result = redactJSONContent(
+		dummySetting,
+		"Header",
+		dummyDescription,
+	)
+
+	// assert
+	assert.Equal(t, `{"Authorization":["***"],"Content-Type":["application/json"]}`, result)
+}
+
+func TestRedactLogDescription_HeaderName(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		HeaderNames: []string{"Authorization"},
+	}
+
+	// SUT + act
+	var result = redactLogDescription(
+		dummySetting,
+		"Header",
+		"authorization",
+		"Bearer abc",
+	)
+
+	// assert
+	assert.Equal(t, defaultRedactionMask, result)
+}
+
+func TestRedactLogDescription_Patterns(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		FieldPaths: []string{"password"},
+		Patterns: []*regexp.Regexp{
+			nil,
+			regexp.MustCompile(`\d{4}-\d{4}-\d{4}-\d{4}`),
+			regexp.MustCompile(`[a-z]+@example\.com`),
+		},
+	}
+
+	// SUT + act
+	var result = redactLogDescription(
+		dummySetting,
+		"Body",
+		"Content",
+		`{"card":"1234-5678-9012-3456","email":"foo@example.com","password":"bar"}`,
+	)
+
+	// assert
+	assert.Equal(t, `{"card":"[REDACTED]","email":"[REDACTED]","password":"[REDACTED]"}`, result)
+}
+
+func TestRedactLogEntry_NotRedactedLogType(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+
+	// SUT + act
+	var result = redactLogEntry(
+		dummySession,
+		LogTypeMethodLogic,
+		"Header",
+		"Authorization",
+		"Bearer abc",
+	)
+
+	// assert
+	assert.Equal(t, "Bearer abc", result)
+}
+
+func TestRedactLogEntry_NoSetting(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &DefaultCustomization{},
+	}
+
+	// SUT + act
+	var result = redactLogEntry(
+		dummySession,
+		LogTypeEndpointRequest,
+		"Header",
+		"Authorization",
+		"Bearer abc",
+	)
+
+	// assert
+	assert.Equal(t, "Bearer abc", result)
+}
+
+func TestRedactLogEntry_Redacted(t *testing.T) {
+	// arrange
+	var dummySetting = &RedactionSetting{
+		HeaderNames: []string{"Authorization"},
+	}
+	var dummySession = &session{
+		customization: &dummyRedactionCustomization{
+			setting: dummySetting,
+		},
+	}
+	var dummyRedacted = "some redacted description"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(redactLogDescription).Expects(dummySetting, "Header", "Authorization", "Bearer abc").Returns(dummyRedacted).Times(4)
+
+	// SUT + act
+	var results = []string{}
+	for _, logType := range []LogType{LogTypeEndpointRequest, LogTypeEndpointResponse, LogTypeWebcallRequest, LogTypeWebcallResponse} {
+		results = append(results, redactLogEntry(
+			dummySession,
+			logType,
+			"Header",
+			"Authorization",
+			"Bearer abc",
+		))
+	}
+
+	// assert
+	assert.Equal(t, []string{dummyRedacted, dummyRedacted, dummyRedacted, dummyRedacted}, results)
+}
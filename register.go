@@ -91,6 +91,9 @@ func registerRoutes(
 			app.handleSession,
 		)
 		app.actionFuncMap[name] = configuredRoute.ActionFunc
+		if configuredRoute.RequireClientCert {
+			app.clientCertRoutes[name] = true
+		}
 	}
 }
 
@@ -151,10 +154,11 @@ func registerErrorHandlers(
 	}
 }
 
-// instantiateRouter instantiates and registers the given routes according to custom specification
+// instantiateRouter instantiates and registers the given routes according to custom specification, along with the health and metrics endpoints unless they are hosted by the admin server
 func instantiateRouter(
 	app *application,
 	session *session,
+	hostOperationals bool,
 ) (chi.Router, error) {
 	var router = session.customization.InstrumentRouter(
 		chi.NewRouter(),
@@ -163,6 +167,12 @@ func instantiateRouter(
 		session,
 		router,
 	)
+	if hostOperationals {
+		registerHealthChecks(
+			app,
+			router,
+		)
+	}
 	registerRoutes(
 		app,
 		session,
@@ -172,6 +182,12 @@ func instantiateRouter(
 		session,
 		router,
 	)
+	if hostOperationals {
+		registerMetrics(
+			session,
+			router,
+		)
+	}
 	registerErrorHandlers(
 		session.customization,
 		router,
@@ -125,7 +125,8 @@ func TestRegisterRoutes_EmptyRoutes(t *testing.T) {
 func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 	// arrange
 	var dummyApplication = &application{
-		actionFuncMap: make(map[string]ActionFunc),
+		actionFuncMap:    make(map[string]ActionFunc),
+		clientCertRoutes: make(map[string]bool),
 	}
 	var dummyCustomization = &DefaultCustomization{}
 	var dummySession = &session{
@@ -159,10 +160,11 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 			ActionFunc: dummyActionFunc1,
 		},
 		{
-			Method:     dummyMethod2,
-			Path:       dummyPath2,
-			Parameters: dummyParameters2,
-			ActionFunc: dummyActionFunc2,
+			Method:            dummyMethod2,
+			Path:              dummyPath2,
+			Parameters:        dummyParameters2,
+			ActionFunc:        dummyActionFunc2,
+			RequireClientCert: true,
 		},
 	}
 	var dummyEvaluatedPath1 = "some evaluated path 1"
@@ -194,6 +196,7 @@ func TestRegisterRoutes_ValidRoutes(t *testing.T) {
 	// assert
 	assert.Contains(t, dummyApplication.actionFuncMap, dummyName1)
 	assert.Contains(t, dummyApplication.actionFuncMap, dummyName2)
+	assert.Equal(t, map[string]bool{dummyName2: true}, dummyApplication.clientCertRoutes)
 }
 
 func TestRegisterStatics_EmptyStatics(t *testing.T) {
@@ -382,8 +385,10 @@ func TestInstantiateRouter_RouterError(t *testing.T) {
 	m.Mock(chi.NewRouter).Expects().Returns(dummyRouter).Once()
 	m.Mock((*DefaultCustomization).InstrumentRouter).Expects(dummyCustomization, dummyRouter).Returns(dummyRouter).Once()
 	m.Mock(registerMiddlewares).Expects(dummySession, dummyRouter).Returns().Once()
+	m.Mock(registerHealthChecks).Expects(dummyApplication, dummyRouter).Returns().Once()
 	m.Mock(registerRoutes).Expects(dummyApplication, dummySession, dummyRouter).Returns().Once()
 	m.Mock(registerStatics).Expects(dummySession, dummyRouter).Returns().Once()
+	m.Mock(registerMetrics).Expects(dummySession, dummyRouter).Returns().Once()
 	m.Mock(walkRegisteredRoutes).Expects(dummySession, dummyRouter).Returns(dummyError).Once()
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelError, "register", "instantiateRouter", "%+v", dummyError).Returns().Once()
 	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageRouteRegistration, dummyError).Returns(dummyAppError).Once()
@@ -392,6 +397,7 @@ func TestInstantiateRouter_RouterError(t *testing.T) {
 	var result, err = instantiateRouter(
 		dummyApplication,
 		dummySession,
+		true,
 	)
 
 	// assert
@@ -415,8 +421,10 @@ func TestInstantiateRouter_HappyPath(t *testing.T) {
 	m.Mock(chi.NewRouter).Expects().Returns(dummyRouter).Once()
 	m.Mock((*DefaultCustomization).InstrumentRouter).Expects(dummyCustomization, dummyRouter).Returns(dummyRouter).Once()
 	m.Mock(registerMiddlewares).Expects(dummySession, dummyRouter).Returns().Once()
+	m.Mock(registerHealthChecks).Expects(dummyApplication, dummyRouter).Returns().Once()
 	m.Mock(registerRoutes).Expects(dummyApplication, dummySession, dummyRouter).Returns().Once()
 	m.Mock(registerStatics).Expects(dummySession, dummyRouter).Returns().Once()
+	m.Mock(registerMetrics).Expects(dummySession, dummyRouter).Returns().Once()
 	m.Mock(walkRegisteredRoutes).Expects(dummySession, dummyRouter).Returns(nil).Once()
 	m.Mock(registerErrorHandlers).Expects(dummyCustomization, dummyRouter).Returns().Once()
 
@@ -424,6 +432,42 @@ func TestInstantiateRouter_HappyPath(t *testing.T) {
 	var result, err = instantiateRouter(
 		dummyApplication,
 		dummySession,
+		true,
+	)
+
+	// assert
+	assert.Equal(t, dummyRouter, result)
+	assert.NoError(t, err)
+}
+
+func TestInstantiateRouter_NoOperationals(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummyApplication = &application{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyRouter = &chi.Mux{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(chi.NewRouter).Expects().Returns(dummyRouter).Once()
+	m.Mock((*DefaultCustomization).InstrumentRouter).Expects(dummyCustomization, dummyRouter).Returns(dummyRouter).Once()
+	m.Mock(registerMiddlewares).Expects(dummySession, dummyRouter).Returns().Once()
+	m.Mock(registerHealthChecks).NotCalled()
+	m.Mock(registerRoutes).Expects(dummyApplication, dummySession, dummyRouter).Returns().Once()
+	m.Mock(registerStatics).Expects(dummySession, dummyRouter).Returns().Once()
+	m.Mock(registerMetrics).NotCalled()
+	m.Mock(walkRegisteredRoutes).Expects(dummySession, dummyRouter).Returns(nil).Once()
+	m.Mock(registerErrorHandlers).Expects(dummyCustomization, dummyRouter).Returns().Once()
+
+	// SUT + act
+	var result, err = instantiateRouter(
+		dummyApplication,
+		dummySession,
+		false,
 	)
 
 	// assert
@@ -9,6 +9,11 @@ import (
 // These are the constants used by the HTTP modules
 const (
 	ContentTypeJSON = "application/json; charset=utf-8"
+	ContentTypeForm = "application/x-www-form-urlencoded"
+	ContentTypeXML  = "application/xml; charset=utf-8"
+	ContentTypeText = "text/plain; charset=utf-8"
+
+	ContentTypeMultipart = "multipart/form-data"
 )
 
 type skipResponseHandlingDummy struct{}
@@ -20,6 +25,18 @@ func SkipResponseHandling() (any, error) {
 	return skipResponseHandlingDummy{}, nil
 }
 
+// Response is the envelope an action function could return as the response object to control the HTTP status code, headers and cookies of the response, while the body is still interpreted, encoded and logged as usual
+type Response struct {
+	// Status is the HTTP status code to respond with upon success; if not set, the status code interpreted from the body is used
+	Status int
+	// Headers are set onto the HTTP response headers, replacing existing values of the same names, e.g. Location
+	Headers http.Header
+	// Cookies are set onto the HTTP response via Set-Cookie headers
+	Cookies []*http.Cookie
+	// Body is the response content to be interpreted and encoded as if it was returned directly
+	Body any
+}
+
 func shouldSkipHandling(
 	responseObject any,
 	responseError error,
@@ -46,6 +63,80 @@ func constructResponse(
 	)
 }
 
+func constructContentResponse(
+	session *session,
+	responseObject any,
+	responseError error,
+	encoder ResponseEncoder,
+) (int, string, []byte) {
+	if responseError != nil {
+		return session.customization.InterpretErrorContent(
+			responseError,
+			encoder,
+		)
+	}
+	return session.customization.InterpretSuccessContent(
+		responseObject,
+		encoder,
+	)
+}
+
+func constructEncodedResponse(
+	session *session,
+	responseObject any,
+	responseError error,
+) (int, string, []byte) {
+	var encoder = negotiateResponseEncoder(
+		session,
+	)
+	var _, isJSON = encoder.(*JSONResponseEncoder)
+	if !isJSON {
+		return constructContentResponse(
+			session,
+			responseObject,
+			responseError,
+			encoder,
+		)
+	}
+	var statusCode, responseMessage = constructResponse(
+		session,
+		responseObject,
+		responseError,
+	)
+	return statusCode, ContentTypeJSON, []byte(responseMessage)
+}
+
+func getResponseEnvelope(responseObject any) *Response {
+	switch envelope := responseObject.(type) {
+	case Response:
+		return &envelope
+	case *Response:
+		return envelope
+	}
+	return nil
+}
+
+func applyResponseEnvelope(
+	responseWriter http.ResponseWriter,
+	envelope *Response,
+) {
+	if envelope == nil {
+		return
+	}
+	for name, values := range envelope.Headers {
+		responseWriter.Header().Del(name)
+		for _, value := range values {
+			responseWriter.Header().Add(name, value)
+		}
+	}
+	for _, cookie := range envelope.Cookies {
+		http.SetCookie(
+			responseWriter,
+			cookie,
+		)
+	}
+}
+
 // writeResponse responds to the consumer with corresponding HTTP status code and response body
 func writeResponse(
 	session *session,
@@ -64,20 +155,46 @@ func writeResponse(
 		)
 		return
 	}
-	var statusCode, responseMessage = constructResponse(
+	var envelope = getResponseEnvelope(
+		responseObject,
+	)
+	if envelope != nil {
+		responseObject = envelope.Body
+	}
+	var statusCode, contentType, responseBody = constructEncodedResponse(
 		session,
 		responseObject,
 		responseError,
 	)
+	if envelope != nil &&
+		envelope.Status != 0 &&
+		responseError == nil {
+		statusCode = envelope.Status
+	}
+	session.statusCode = statusCode
+	recordSpanError(
+		session.span,
+		responseError,
+	)
+	countAppError(
+		session,
+		responseError,
+	)
 	logEndpointResponse(
 		session,
 		http.StatusText(statusCode),
 		strconv.Itoa(statusCode),
 		"%s",
-		responseMessage,
+		responseBody,
 	)
 	var responseWriter = session.GetResponseWriter()
-	responseWriter.Header().Set("Content-Type", ContentTypeJSON)
+	if contentType != "" {
+		responseWriter.Header().Set("Content-Type", contentType)
+	}
+	applyResponseEnvelope(
+		responseWriter,
+		envelope,
+	)
 	responseWriter.WriteHeader(statusCode)
-	responseWriter.Write([]byte(responseMessage))
+	responseWriter.Write(responseBody)
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math/rand/v2"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 
@@ -122,6 +123,221 @@ func TestConstructResponse_Success(t *testing.T) {
 	assert.Equal(t, dummyMessage, message)
 }
 
+func TestGetResponseEnvelope_Value(t *testing.T) {
+	// arrange
+	var dummyEnvelope = Response{
+		Status: rand.Int(),
+		Body:   rand.Int(),
+	}
+
+	// SUT + act
+	var result = getResponseEnvelope(
+		dummyEnvelope,
+	)
+
+	// assert
+	assert.Equal(t, &dummyEnvelope, result)
+}
+
+func TestGetResponseEnvelope_Pointer(t *testing.T) {
+	// arrange
+	var dummyEnvelope = &Response{
+		Status: rand.Int(),
+		Body:   rand.Int(),
+	}
+
+	// SUT + act
+	var result = getResponseEnvelope(
+		dummyEnvelope,
+	)
+
+	// assert
+	assert.Equal(t, dummyEnvelope, result)
+}
+
+func TestGetResponseEnvelope_Other(t *testing.T) {
+	// arrange
+	var dummyResponseObject = rand.Int()
+
+	// SUT + act
+	var result = getResponseEnvelope(
+		dummyResponseObject,
+	)
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestApplyResponseEnvelope_NilEnvelope(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = httptest.NewRecorder()
+
+	// SUT + act
+	applyResponseEnvelope(
+		dummyResponseWriter,
+		nil,
+	)
+
+	// assert
+	assert.Empty(t, dummyResponseWriter.Header())
+}
+
+func TestApplyResponseEnvelope_ValidEnvelope(t *testing.T) {
+	// arrange
+	var dummyResponseWriter = httptest.NewRecorder()
+	var dummyEnvelope = &Response{
+		Headers: http.Header{
+			"Content-Type": {"some content type"},
+			"Location":     {"some location"},
+			"Foo":          {"bar", "baz"},
+		},
+		Cookies: []*http.Cookie{
+			{Name: "some", Value: "cookie"},
+		},
+	}
+
+	// stub
+	dummyResponseWriter.Header().Set("Content-Type", ContentTypeJSON)
+
+	// SUT + act
+	applyResponseEnvelope(
+		dummyResponseWriter,
+		dummyEnvelope,
+	)
+
+	// assert
+	assert.Equal(t, http.Header{
+		"Content-Type": {"some content type"},
+		"Location":     {"some location"},
+		"Foo":          {"bar", "baz"},
+		"Set-Cookie":   {"some=cookie"},
+	}, dummyResponseWriter.Header())
+}
+
+func TestConstructContentResponse_ResponseError(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyResponseObject = rand.Int()
+	var dummyResponseError = errors.New("some response error")
+	var dummyEncoder = &XMLResponseEncoder{}
+	var dummyCode = rand.Int()
+	var dummyContentType = "some content type"
+	var dummyBody = []byte("some body")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).InterpretErrorContent).Expects(dummyCustomization, dummyResponseError, dummyEncoder).Returns(dummyCode, dummyContentType, dummyBody).Once()
+
+	// SUT + act
+	var code, contentType, body = constructContentResponse(
+		dummySession,
+		dummyResponseObject,
+		dummyResponseError,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, dummyCode, code)
+	assert.Equal(t, dummyContentType, contentType)
+	assert.Equal(t, dummyBody, body)
+}
+
+func TestConstructContentResponse_Success(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyResponseObject = rand.Int()
+	var dummyEncoder = &XMLResponseEncoder{}
+	var dummyCode = rand.Int()
+	var dummyContentType = "some content type"
+	var dummyBody = []byte("some body")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).InterpretSuccessContent).Expects(dummyCustomization, dummyResponseObject, dummyEncoder).Returns(dummyCode, dummyContentType, dummyBody).Once()
+
+	// SUT + act
+	var code, contentType, body = constructContentResponse(
+		dummySession,
+		dummyResponseObject,
+		nil,
+		dummyEncoder,
+	)
+
+	// assert
+	assert.Equal(t, dummyCode, code)
+	assert.Equal(t, dummyContentType, contentType)
+	assert.Equal(t, dummyBody, body)
+}
+
+func TestConstructEncodedResponse_OtherEncoder(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyResponseObject = rand.Int()
+	var dummyResponseError = errors.New("some response error")
+	var dummyEncoder = &XMLResponseEncoder{}
+	var dummyCode = rand.Int()
+	var dummyContentType = "some content type"
+	var dummyBody = []byte("some body")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(negotiateResponseEncoder).Expects(dummySession).Returns(dummyEncoder).Once()
+	m.Mock(constructContentResponse).Expects(dummySession, dummyResponseObject, dummyResponseError, dummyEncoder).Returns(dummyCode, dummyContentType, dummyBody).Once()
+
+	// SUT + act
+	var code, contentType, body = constructEncodedResponse(
+		dummySession,
+		dummyResponseObject,
+		dummyResponseError,
+	)
+
+	// assert
+	assert.Equal(t, dummyCode, code)
+	assert.Equal(t, dummyContentType, contentType)
+	assert.Equal(t, dummyBody, body)
+}
+
+func TestConstructEncodedResponse_JSONEncoder(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyResponseObject = rand.Int()
+	var dummyResponseError = errors.New("some response error")
+	var dummyEncoder = &JSONResponseEncoder{}
+	var dummyCode = rand.Int()
+	var dummyMessage = "some message"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(negotiateResponseEncoder).Expects(dummySession).Returns(dummyEncoder).Once()
+	m.Mock(constructResponse).Expects(dummySession, dummyResponseObject, dummyResponseError).Returns(dummyCode, dummyMessage).Once()
+
+	// SUT + act
+	var code, contentType, body = constructEncodedResponse(
+		dummySession,
+		dummyResponseObject,
+		dummyResponseError,
+	)
+
+	// assert
+	assert.Equal(t, dummyCode, code)
+	assert.Equal(t, ContentTypeJSON, contentType)
+	assert.Equal(t, []byte(dummyMessage), body)
+}
+
 func TestWriteResponse_SkipHandling(t *testing.T) {
 	// arrange
 	var dummyResponseWriter = &dummyResponseWriter{}
@@ -155,7 +371,8 @@ func TestWriteResponse_HappyPath(t *testing.T) {
 	var dummyResponseObject = rand.Int()
 	var dummyResponseError = errors.New("some response error")
 	var dummyCode = rand.Int()
-	var dummyMessage = "some message"
+	var dummyContentType = "some content type"
+	var dummyMessage = []byte("some message")
 	var dummyStatusText = "some status text"
 	var dummyCodeString = strconv.Itoa(dummyCode)
 	var dummyHeader = make(http.Header)
@@ -165,14 +382,18 @@ func TestWriteResponse_HappyPath(t *testing.T) {
 
 	// expect
 	m.Mock(shouldSkipHandling).Expects(dummyResponseObject, dummyResponseError).Returns(false).Once()
-	m.Mock(constructResponse).Expects(dummySession, dummyResponseObject, dummyResponseError).Returns(dummyCode, dummyMessage).Once()
+	m.Mock(getResponseEnvelope).Expects(dummyResponseObject).Returns(nil).Once()
+	m.Mock(constructEncodedResponse).Expects(dummySession, dummyResponseObject, dummyResponseError).Returns(dummyCode, dummyContentType, dummyMessage).Once()
+	m.Mock(recordSpanError).Expects(nil, dummyResponseError).Returns().Once()
+	m.Mock(countAppError).Expects(dummySession, dummyResponseError).Returns().Once()
 	m.Mock(http.StatusText).Expects(dummyCode).Returns(dummyStatusText).Once()
 	m.Mock(strconv.Itoa).Expects(dummyCode).Returns(dummyCodeString).Once()
 	m.Mock(logEndpointResponse).Expects(dummySession, dummyStatusText, dummyCodeString, "%s", dummyMessage).Returns().Once()
 	m.Mock(isInterfaceValueNil).Expects(dummyResponseWriterInstance).Returns(false).Once()
 	m.Mock((*dummyResponseWriter).Header).Expects(dummyResponseWriterInstance).Returns(dummyHeader).Once()
+	m.Mock(applyResponseEnvelope).Expects(dummyResponseWriterInstance, (*Response)(nil)).Returns().Once()
 	m.Mock((*dummyResponseWriter).WriteHeader).Expects(dummyResponseWriterInstance, dummyCode).Returns().Once()
-	m.Mock((*dummyResponseWriter).Write).Expects(dummyResponseWriterInstance, []byte(dummyMessage)).Returns(rand.Int(), errors.New("some error")).Once()
+	m.Mock((*dummyResponseWriter).Write).Expects(dummyResponseWriterInstance, dummyMessage).Returns(rand.Int(), errors.New("some error")).Once()
 
 	// SUT + act
 	writeResponse(
@@ -183,5 +404,125 @@ func TestWriteResponse_HappyPath(t *testing.T) {
 
 	// assert
 	assert.Equal(t, 1, len(dummyHeader))
-	assert.Equal(t, ContentTypeJSON, dummyHeader.Get("Content-Type"))
+	assert.Equal(t, dummyContentType, dummyHeader.Get("Content-Type"))
+}
+
+func TestWriteResponse_NoContentType(t *testing.T) {
+	// arrange
+	var dummyResponseWriterInstance = &dummyResponseWriter{}
+	var dummySession = &session{
+		responseWriter: dummyResponseWriterInstance,
+	}
+	var dummyResponseObject = rand.Int()
+	var dummyCode = rand.Int()
+	var dummyStatusText = "some status text"
+	var dummyCodeString = strconv.Itoa(dummyCode)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(shouldSkipHandling).Expects(dummyResponseObject, nil).Returns(false).Once()
+	m.Mock(getResponseEnvelope).Expects(dummyResponseObject).Returns(nil).Once()
+	m.Mock(constructEncodedResponse).Expects(dummySession, dummyResponseObject, nil).Returns(dummyCode, "", []byte(nil)).Once()
+	m.Mock(recordSpanError).Expects(nil, nil).Returns().Once()
+	m.Mock(countAppError).Expects(dummySession, nil).Returns().Once()
+	m.Mock(http.StatusText).Expects(dummyCode).Returns(dummyStatusText).Once()
+	m.Mock(strconv.Itoa).Expects(dummyCode).Returns(dummyCodeString).Once()
+	m.Mock(logEndpointResponse).Expects(dummySession, dummyStatusText, dummyCodeString, "%s", []byte(nil)).Returns().Once()
+	m.Mock(isInterfaceValueNil).Expects(dummyResponseWriterInstance).Returns(false).Once()
+	m.Mock(applyResponseEnvelope).Expects(dummyResponseWriterInstance, (*Response)(nil)).Returns().Once()
+	m.Mock((*dummyResponseWriter).WriteHeader).Expects(dummyResponseWriterInstance, dummyCode).Returns().Once()
+	m.Mock((*dummyResponseWriter).Write).Expects(dummyResponseWriterInstance, []byte(nil)).Returns(0, nil).Once()
+
+	// SUT + act
+	writeResponse(
+		dummySession,
+		dummyResponseObject,
+		nil,
+	)
+}
+
+func TestWriteResponse_Envelope(t *testing.T) {
+	// arrange
+	var dummyResponseWriterInstance = &dummyResponseWriter{}
+	var dummySession = &session{
+		responseWriter: dummyResponseWriterInstance,
+	}
+	var dummyBody = rand.Int()
+	var dummyStatus = rand.IntN(100) + 200
+	var dummyEnvelope = &Response{
+		Status: dummyStatus,
+		Body:   dummyBody,
+	}
+	var dummyCode = rand.Int()
+	var dummyMessage = []byte("some message")
+	var dummyStatusText = "some status text"
+	var dummyCodeString = strconv.Itoa(dummyStatus)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(shouldSkipHandling).Expects(dummyEnvelope, nil).Returns(false).Once()
+	m.Mock(getResponseEnvelope).Expects(dummyEnvelope).Returns(dummyEnvelope).Once()
+	m.Mock(constructEncodedResponse).Expects(dummySession, dummyBody, nil).Returns(dummyCode, "", dummyMessage).Once()
+	m.Mock(recordSpanError).Expects(nil, nil).Returns().Once()
+	m.Mock(countAppError).Expects(dummySession, nil).Returns().Once()
+	m.Mock(http.StatusText).Expects(dummyStatus).Returns(dummyStatusText).Once()
+	m.Mock(strconv.Itoa).Expects(dummyStatus).Returns(dummyCodeString).Once()
+	m.Mock(logEndpointResponse).Expects(dummySession, dummyStatusText, dummyCodeString, "%s", dummyMessage).Returns().Once()
+	m.Mock(isInterfaceValueNil).Expects(dummyResponseWriterInstance).Returns(false).Once()
+	m.Mock(applyResponseEnvelope).Expects(dummyResponseWriterInstance, dummyEnvelope).Returns().Once()
+	m.Mock((*dummyResponseWriter).WriteHeader).Expects(dummyResponseWriterInstance, dummyStatus).Returns().Once()
+	m.Mock((*dummyResponseWriter).Write).Expects(dummyResponseWriterInstance, dummyMessage).Returns(0, nil).Once()
+
+	// SUT + act
+	writeResponse(
+		dummySession,
+		dummyEnvelope,
+		nil,
+	)
+}
+
+func TestWriteResponse_EnvelopeWithError(t *testing.T) {
+	// arrange
+	var dummyResponseWriterInstance = &dummyResponseWriter{}
+	var dummySession = &session{
+		responseWriter: dummyResponseWriterInstance,
+	}
+	var dummyBody = rand.Int()
+	var dummyEnvelope = &Response{
+		Status: rand.IntN(100) + 200,
+		Body:   dummyBody,
+	}
+	var dummyResponseError = errors.New("some response error")
+	var dummyCode = rand.Int()
+	var dummyMessage = []byte("some message")
+	var dummyStatusText = "some status text"
+	var dummyCodeString = strconv.Itoa(dummyCode)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(shouldSkipHandling).Expects(dummyEnvelope, dummyResponseError).Returns(false).Once()
+	m.Mock(getResponseEnvelope).Expects(dummyEnvelope).Returns(dummyEnvelope).Once()
+	m.Mock(constructEncodedResponse).Expects(dummySession, dummyBody, dummyResponseError).Returns(dummyCode, "", dummyMessage).Once()
+	m.Mock(recordSpanError).Expects(nil, dummyResponseError).Returns().Once()
+	m.Mock(countAppError).Expects(dummySession, dummyResponseError).Returns().Once()
+	m.Mock(http.StatusText).Expects(dummyCode).Returns(dummyStatusText).Once()
+	m.Mock(strconv.Itoa).Expects(dummyCode).Returns(dummyCodeString).Once()
+	m.Mock(logEndpointResponse).Expects(dummySession, dummyStatusText, dummyCodeString, "%s", dummyMessage).Returns().Once()
+	m.Mock(isInterfaceValueNil).Expects(dummyResponseWriterInstance).Returns(false).Once()
+	m.Mock(applyResponseEnvelope).Expects(dummyResponseWriterInstance, dummyEnvelope).Returns().Once()
+	m.Mock((*dummyResponseWriter).WriteHeader).Expects(dummyResponseWriterInstance, dummyCode).Returns().Once()
+	m.Mock((*dummyResponseWriter).Write).Expects(dummyResponseWriterInstance, dummyMessage).Returns(0, nil).Once()
+
+	// SUT + act
+	writeResponse(
+		dummySession,
+		dummyEnvelope,
+		dummyResponseError,
+	)
 }
@@ -0,0 +1,148 @@
+package webserver
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy determines whether and after how long a failed webcall attempt should be retried, once the retry counts set up through SetupRetry allow it
+type RetryPolicy interface {
+	// NextDelay returns the delay before the next attempt, given the number of attempts made so far, the total time elapsed since the first attempt, the previous retry delay and the last response (nil upon connectivity errors); returns false if no more retry should happen
+	NextDelay(attempt int, elapsed time.Duration, previousDelay time.Duration, response *http.Response) (time.Duration, bool)
+}
+
+// RetryJitter is the randomization strategy applied to the delays calculated by BackoffRetryPolicy
+type RetryJitter int
+
+// These are the enum definitions of retry jitter strategies
+const (
+	// RetryJitterNone uses the calculated delay as is
+	RetryJitterNone RetryJitter = iota
+	// RetryJitterFull picks a random delay between zero and the calculated delay
+	RetryJitterFull
+	// RetryJitterDecorrelated picks a random delay between the initial delay and three times the previous delay
+	RetryJitterDecorrelated
+)
+
+// BackoffRetryPolicy is the built-in RetryPolicy supporting fixed or exponential delays, jitter, total elapsed time limit and Retry-After headers
+type BackoffRetryPolicy struct {
+	// InitialDelay is the delay before the first retry
+	InitialDelay time.Duration
+	// Multiplier is the growth factor of the delay between consecutive retries; values not greater than 1 result in fixed delays
+	Multiplier float64
+	// MaxDelay caps every single retry delay; zero means no cap
+	MaxDelay time.Duration
+	// Jitter is the randomization strategy applied to the calculated delays
+	Jitter RetryJitter
+	// MaxElapsedTime stops retrying once the next attempt would start later than this duration after the first attempt; zero means no limit
+	MaxElapsedTime time.Duration
+	// HonorRetryAfter uses the Retry-After header of the last response, e.g. 429 or 503, as the delay when present, still capped by MaxDelay
+	HonorRetryAfter bool
+}
+
+func capRetryDelay(delay time.Duration, maxDelay time.Duration) time.Duration {
+	if delay < 0 {
+		return 0
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// scaleRetryDelay multiplies the delay by the factor, saturating at the longest representable duration instead of overflowing
+func scaleRetryDelay(delay time.Duration, factor float64) time.Duration {
+	var scaled = float64(delay) * factor
+	if scaled >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(scaled)
+}
+
+func randomRetryDelay(lowerBound time.Duration, upperBound time.Duration) time.Duration {
+	if upperBound <= lowerBound {
+		return lowerBound
+	}
+	return lowerBound + time.Duration(
+		rand.Int64N(
+			int64(upperBound-lowerBound),
+		),
+	)
+}
+
+func getRetryAfter(response *http.Response) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+	var retryAfter = strings.TrimSpace(
+		response.Header.Get("Retry-After"),
+	)
+	if retryAfter == "" {
+		return 0, false
+	}
+	var seconds, parseError = strconv.Atoi(retryAfter)
+	if parseError == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	var retryTime, timeError = http.ParseTime(retryAfter)
+	if timeError != nil {
+		return 0, false
+	}
+	return time.Until(retryTime), true
+}
+
+func (retryPolicy *BackoffRetryPolicy) calculateDelay(attempt int, previousDelay time.Duration) time.Duration {
+	var delay = retryPolicy.InitialDelay
+	if retryPolicy.Multiplier > 1 && attempt > 1 {
+		delay = scaleRetryDelay(
+			retryPolicy.InitialDelay,
+			math.Pow(retryPolicy.Multiplier, float64(attempt-1)),
+		)
+	}
+	switch retryPolicy.Jitter {
+	case RetryJitterFull:
+		delay = randomRetryDelay(
+			0,
+			capRetryDelay(delay, retryPolicy.MaxDelay),
+		)
+	case RetryJitterDecorrelated:
+		if previousDelay < retryPolicy.InitialDelay {
+			previousDelay = retryPolicy.InitialDelay
+		}
+		delay = randomRetryDelay(
+			retryPolicy.InitialDelay,
+			scaleRetryDelay(previousDelay, 3),
+		)
+	}
+	return capRetryDelay(
+		delay,
+		retryPolicy.MaxDelay,
+	)
+}
+
+// NextDelay returns the delay before the next attempt, given the number of attempts made so far, the total time elapsed since the first attempt, the previous retry delay and the last response (nil upon connectivity errors); returns false if no more retry should happen
+func (retryPolicy *BackoffRetryPolicy) NextDelay(attempt int, elapsed time.Duration, previousDelay time.Duration, response *http.Response) (time.Duration, bool) {
+	var delay, found = time.Duration(0), false
+	if retryPolicy.HonorRetryAfter {
+		delay, found = getRetryAfter(
+			response,
+		)
+	}
+	if found {
+		delay = capRetryDelay(delay, retryPolicy.MaxDelay)
+	} else {
+		delay = retryPolicy.calculateDelay(
+			attempt,
+			previousDelay,
+		)
+	}
+	if retryPolicy.MaxElapsedTime > 0 &&
+		delay > retryPolicy.MaxElapsedTime-elapsed {
+		return 0, false
+	}
+	return delay, true
+}
@@ -0,0 +1,486 @@
+package webserver
+
+import (
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestCapRetryDelay_Negative(t *testing.T) {
+	// arrange
+	var dummyDelay = -time.Duration(rand.IntN(100) + 1)
+	var dummyMaxDelay = time.Duration(rand.IntN(100))
+
+	// SUT + act
+	var result = capRetryDelay(
+		dummyDelay,
+		dummyMaxDelay,
+	)
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestCapRetryDelay_ExceedMaxDelay(t *testing.T) {
+	// arrange
+	var dummyMaxDelay = time.Duration(rand.IntN(100) + 1)
+	var dummyDelay = dummyMaxDelay + time.Duration(rand.IntN(100)+1)
+
+	// SUT + act
+	var result = capRetryDelay(
+		dummyDelay,
+		dummyMaxDelay,
+	)
+
+	// assert
+	assert.Equal(t, dummyMaxDelay, result)
+}
+
+func TestCapRetryDelay_NoMaxDelay(t *testing.T) {
+	// arrange
+	var dummyDelay = time.Duration(rand.IntN(100))
+
+	// SUT + act
+	var result = capRetryDelay(
+		dummyDelay,
+		0,
+	)
+
+	// assert
+	assert.Equal(t, dummyDelay, result)
+}
+
+func TestRandomRetryDelay_InvalidRange(t *testing.T) {
+	// arrange
+	var dummyLowerBound = time.Duration(rand.IntN(100) + 100)
+	var dummyUpperBound = time.Duration(rand.IntN(100))
+
+	// SUT + act
+	var result = randomRetryDelay(
+		dummyLowerBound,
+		dummyUpperBound,
+	)
+
+	// assert
+	assert.Equal(t, dummyLowerBound, result)
+}
+
+func TestRandomRetryDelay_ValidRange(t *testing.T) {
+	// arrange
+	var dummyLowerBound = time.Duration(rand.IntN(100))
+	var dummyUpperBound = time.Duration(rand.IntN(100) + 100)
+	var dummyRandom = rand.Int64N(100)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(rand.Int64N).Expects(int64(dummyUpperBound - dummyLowerBound)).Returns(dummyRandom).Once()
+
+	// SUT + act
+	var result = randomRetryDelay(
+		dummyLowerBound,
+		dummyUpperBound,
+	)
+
+	// assert
+	assert.Equal(t, dummyLowerBound+time.Duration(dummyRandom), result)
+}
+
+func TestGetRetryAfter_NilResponse(t *testing.T) {
+	// SUT + act
+	var result, found = getRetryAfter(
+		nil,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, found)
+}
+
+func TestGetRetryAfter_NoHeader(t *testing.T) {
+	// arrange
+	var dummyResponse = &http.Response{
+		Header: http.Header{},
+	}
+
+	// SUT + act
+	var result, found = getRetryAfter(
+		dummyResponse,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, found)
+}
+
+func TestGetRetryAfter_Seconds(t *testing.T) {
+	// arrange
+	var dummySeconds = rand.IntN(100)
+	var dummyResponse = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{" " + strconv.Itoa(dummySeconds) + " "},
+		},
+	}
+
+	// SUT + act
+	var result, found = getRetryAfter(
+		dummyResponse,
+	)
+
+	// assert
+	assert.Equal(t, time.Duration(dummySeconds)*time.Second, result)
+	assert.True(t, found)
+}
+
+func TestGetRetryAfter_HTTPDate(t *testing.T) {
+	// arrange
+	var dummyRetryTime = time.Now().Add(time.Hour)
+	var dummyResponse = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{dummyRetryTime.UTC().Format(http.TimeFormat)},
+		},
+	}
+
+	// SUT + act
+	var result, found = getRetryAfter(
+		dummyResponse,
+	)
+
+	// assert
+	assert.InDelta(t, float64(time.Hour), float64(result), float64(2*time.Second))
+	assert.True(t, found)
+}
+
+func TestGetRetryAfter_Invalid(t *testing.T) {
+	// arrange
+	var dummyResponse = &http.Response{
+		Header: http.Header{
+			"Retry-After": []string{"some invalid value"},
+		},
+	}
+
+	// SUT + act
+	var result, found = getRetryAfter(
+		dummyResponse,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, found)
+}
+
+func TestScaleRetryDelay_Normal(t *testing.T) {
+	// arrange
+	var dummyDelay = time.Duration(rand.IntN(100))
+
+	// SUT + act
+	var result = scaleRetryDelay(
+		dummyDelay,
+		2.5,
+	)
+
+	// assert
+	assert.Equal(t, time.Duration(float64(dummyDelay)*2.5), result)
+}
+
+func TestScaleRetryDelay_Overflow(t *testing.T) {
+	// arrange
+	var dummyDelay = time.Second
+
+	// SUT + act
+	var result = scaleRetryDelay(
+		dummyDelay,
+		math.Pow(2, 40),
+	)
+
+	// assert
+	assert.Equal(t, time.Duration(math.MaxInt64), result)
+}
+
+func TestBackoffRetryPolicyCalculateDelay_Fixed(t *testing.T) {
+	// arrange
+	var dummyInitialDelay = time.Duration(rand.IntN(100))
+	var dummyAttempt = rand.IntN(100)
+	var dummyPreviousDelay = time.Duration(rand.IntN(100))
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		InitialDelay: dummyInitialDelay,
+	}
+
+	// act
+	var result = sut.calculateDelay(
+		dummyAttempt,
+		dummyPreviousDelay,
+	)
+
+	// assert
+	assert.Equal(t, dummyInitialDelay, result)
+}
+
+func TestBackoffRetryPolicyCalculateDelay_Exponential(t *testing.T) {
+	// arrange
+	var dummyInitialDelay = time.Duration(rand.IntN(100) + 1)
+	var dummyMaxDelay = dummyInitialDelay * 10
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		InitialDelay: dummyInitialDelay,
+		Multiplier:   2,
+		MaxDelay:     dummyMaxDelay,
+	}
+
+	// act
+	var result1 = sut.calculateDelay(1, 0)
+	var result2 = sut.calculateDelay(2, 0)
+	var result3 = sut.calculateDelay(3, 0)
+	var result4 = sut.calculateDelay(4, 0)
+	var result5 = sut.calculateDelay(5, 0)
+
+	// assert
+	assert.Equal(t, dummyInitialDelay, result1)
+	assert.Equal(t, dummyInitialDelay*2, result2)
+	assert.Equal(t, dummyInitialDelay*4, result3)
+	assert.Equal(t, dummyInitialDelay*8, result4)
+	assert.Equal(t, dummyMaxDelay, result5)
+}
+
+func TestBackoffRetryPolicyCalculateDelay_ExponentialOverflow(t *testing.T) {
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		InitialDelay: time.Second,
+		Multiplier:   2,
+		MaxDelay:     time.Minute,
+	}
+
+	// act
+	var result1 = sut.calculateDelay(40, 0)
+	var result2 = sut.calculateDelay(1000, 0)
+
+	// assert
+	assert.Equal(t, time.Minute, result1)
+	assert.Equal(t, time.Minute, result2)
+}
+
+func TestBackoffRetryPolicyCalculateDelay_FullJitter(t *testing.T) {
+	// arrange
+	var dummyInitialDelay = time.Duration(rand.IntN(100) + 100)
+	var dummyMaxDelay = dummyInitialDelay * 3
+	var dummyRandomDelay = time.Duration(rand.IntN(100))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(randomRetryDelay).Expects(time.Duration(0), dummyMaxDelay).Returns(dummyRandomDelay).Once()
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		InitialDelay: dummyInitialDelay,
+		Multiplier:   2,
+		MaxDelay:     dummyMaxDelay,
+		Jitter:       RetryJitterFull,
+	}
+
+	// act
+	var result = sut.calculateDelay(
+		3,
+		0,
+	)
+
+	// assert
+	assert.Equal(t, dummyRandomDelay, result)
+}
+
+func TestBackoffRetryPolicyCalculateDelay_DecorrelatedJitter(t *testing.T) {
+	// arrange
+	var dummyInitialDelay = time.Duration(rand.IntN(100) + 1)
+	var dummyPreviousDelay = dummyInitialDelay * 2
+	var dummyRandomDelay = time.Duration(rand.IntN(100))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(randomRetryDelay).Expects(dummyInitialDelay, dummyPreviousDelay*3).Returns(dummyRandomDelay).Once()
+	m.Mock(randomRetryDelay).Expects(dummyInitialDelay, dummyInitialDelay*3).Returns(dummyRandomDelay).Once()
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		InitialDelay: dummyInitialDelay,
+		Jitter:       RetryJitterDecorrelated,
+	}
+
+	// act
+	var result1 = sut.calculateDelay(
+		rand.IntN(100),
+		dummyPreviousDelay,
+	)
+	var result2 = sut.calculateDelay(
+		rand.IntN(100),
+		0,
+	)
+
+	// assert
+	assert.Equal(t, dummyRandomDelay, result1)
+	assert.Equal(t, dummyRandomDelay, result2)
+}
+
+func TestBackoffRetryPolicyNextDelay_RetryAfter(t *testing.T) {
+	// arrange
+	var dummyAttempt = rand.IntN(100)
+	var dummyElapsed = time.Duration(rand.IntN(100))
+	var dummyPreviousDelay = time.Duration(rand.IntN(100))
+	var dummyResponse = &http.Response{}
+	var dummyRetryAfter = -time.Duration(rand.IntN(100) + 1)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRetryAfter).Expects(dummyResponse).Returns(dummyRetryAfter, true).Once()
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		HonorRetryAfter: true,
+	}
+
+	// act
+	var result, ok = sut.NextDelay(
+		dummyAttempt,
+		dummyElapsed,
+		dummyPreviousDelay,
+		dummyResponse,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.True(t, ok)
+}
+
+func TestBackoffRetryPolicyNextDelay_RetryAfterCapped(t *testing.T) {
+	// arrange
+	var dummyAttempt = rand.IntN(100)
+	var dummyElapsed = time.Duration(rand.IntN(100))
+	var dummyPreviousDelay = time.Duration(rand.IntN(100))
+	var dummyResponse = &http.Response{}
+	var dummyMaxDelay = time.Duration(rand.IntN(100) + 1)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRetryAfter).Expects(dummyResponse).Returns(24*time.Hour, true).Once()
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		MaxDelay:        dummyMaxDelay,
+		HonorRetryAfter: true,
+	}
+
+	// act
+	var result, ok = sut.NextDelay(
+		dummyAttempt,
+		dummyElapsed,
+		dummyPreviousDelay,
+		dummyResponse,
+	)
+
+	// assert
+	assert.Equal(t, dummyMaxDelay, result)
+	assert.True(t, ok)
+}
+
+func TestBackoffRetryPolicyNextDelay_Calculated(t *testing.T) {
+	// arrange
+	var dummyAttempt = rand.IntN(100)
+	var dummyElapsed = time.Duration(rand.IntN(100))
+	var dummyPreviousDelay = time.Duration(rand.IntN(100))
+	var dummyResponse = &http.Response{}
+	var dummyDelay = time.Duration(rand.IntN(100))
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		HonorRetryAfter: true,
+		MaxElapsedTime:  dummyElapsed + dummyDelay,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getRetryAfter).Expects(dummyResponse).Returns(time.Duration(0), false).Once()
+	m.Mock((*BackoffRetryPolicy).calculateDelay).Expects(sut, dummyAttempt, dummyPreviousDelay).Returns(dummyDelay).Once()
+
+	// act
+	var result, ok = sut.NextDelay(
+		dummyAttempt,
+		dummyElapsed,
+		dummyPreviousDelay,
+		dummyResponse,
+	)
+
+	// assert
+	assert.Equal(t, dummyDelay, result)
+	assert.True(t, ok)
+}
+
+func TestBackoffRetryPolicyNextDelay_MaxElapsedTimeExceeded(t *testing.T) {
+	// arrange
+	var dummyAttempt = rand.IntN(100)
+	var dummyElapsed = time.Duration(rand.IntN(100))
+	var dummyPreviousDelay = time.Duration(rand.IntN(100))
+	var dummyResponse = &http.Response{}
+	var dummyDelay = time.Duration(rand.IntN(100) + 1)
+
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		MaxElapsedTime: dummyElapsed + dummyDelay - 1,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*BackoffRetryPolicy).calculateDelay).Expects(sut, dummyAttempt, dummyPreviousDelay).Returns(dummyDelay).Once()
+
+	// act
+	var result, ok = sut.NextDelay(
+		dummyAttempt,
+		dummyElapsed,
+		dummyPreviousDelay,
+		dummyResponse,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, ok)
+}
+
+func TestBackoffRetryPolicyNextDelay_SaturatedDelayExceedsMaxElapsedTime(t *testing.T) {
+	// SUT
+	var sut = &BackoffRetryPolicy{
+		InitialDelay:   time.Second,
+		Multiplier:     2,
+		MaxElapsedTime: time.Hour,
+	}
+
+	// act
+	var result, ok = sut.NextDelay(
+		1000,
+		time.Minute,
+		0,
+		nil,
+	)
+
+	// assert
+	assert.Zero(t, result)
+	assert.False(t, ok)
+}
@@ -14,12 +14,13 @@ type Static struct {
 	Handler    http.Handler
 }
 
-// Route holds the registration information of a dynamic route hosting
+// Route holds the registration information of a dynamic route hosting; RequireClientCert rejects requests without a client certificate verified against the CA cert pool before PreAction takes place
 type Route struct {
-	Method     string
-	Path       string
-	Parameters map[string]ParameterType
-	ActionFunc ActionFunc
+	Method            string
+	Path              string
+	Parameters        map[string]ParameterType
+	ActionFunc        ActionFunc
+	RequireClientCert bool
 }
 
 func evaluateRoute(
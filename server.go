@@ -2,29 +2,53 @@ package webserver
 
 import (
 	"context"
-	"crypto/tls"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// hostServer hosts the service entries and starts HTTPS server
+// hostServer hosts the service entries and starts the servers on all listeners, along with the admin server if customized
 func hostServer(
 	app *application,
 	session *session,
 	shutdownSignal chan os.Signal,
-	started *bool,
+	started *atomic.Bool,
 ) error {
+	var adminSetting = session.customization.AdminServer()
 	var router, routerError = instantiateRouter(
 		app,
 		session,
+		adminSetting == nil,
 	)
 	if routerError != nil {
 		return routerError
 	}
+	var servers = createServers(
+		app.address,
+		session,
+		router,
+	)
+	if adminSetting != nil {
+		var adminServer, adminError = createAdminServer(
+			app,
+			session,
+			adminSetting,
+			router,
+		)
+		if adminError != nil {
+			return adminError
+		}
+		servers = append(
+			servers,
+			adminServer,
+		)
+	}
 	logAppRoot(
 		session,
 		LogLevelInfo,
@@ -34,9 +58,8 @@ func hostServer(
 		app.address,
 	)
 	if runServer(
-		app.address,
 		session,
-		router,
+		servers,
 		shutdownSignal,
 		started,
 	) {
@@ -63,55 +86,95 @@ func hostServer(
 }
 
 func createServer(
+	session *session,
+	setting ListenerSetting,
+	handler http.Handler,
+) *hostedServer {
+	var tlsConfig, https = createTLSConfig(
+		session,
+		getCertificateProvider(setting),
+		setting.ClientAuthMode,
+	)
+	return &hostedServer{
+		setting: setting,
+		server: &http.Server{
+			Addr:      setting.Address,
+			TLSConfig: tlsConfig,
+			Handler:   handler,
+		},
+		https: https,
+	}
+}
+
+// createServers creates one server per listener, all of them sharing the same wrapped router
+func createServers(
 	address string,
 	session *session,
 	router chi.Router,
-) (*http.Server, bool) {
-	var tlsConfig = &tls.Config{
-		// TLS 1.2 as minimum requirement
-		MinVersion: tls.VersionTLS12,
-	}
-	var https = false
-	var serverCert = session.customization.ServerCert()
-	if serverCert != nil {
-		https = true
-		tlsConfig.Certificates = []tls.Certificate{
-			*serverCert,
-		}
-		var caCertPool = session.customization.CaCertPool()
-		if caCertPool != nil {
-			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
-			tlsConfig.ClientCAs = caCertPool
-		} else {
-			tlsConfig.ClientAuth = tls.RequireAnyClientCert
-		}
+) []*hostedServer {
+	var handler = session.customization.WrapHandler(
+		router,
+	)
+	var servers = []*hostedServer{}
+	for _, setting := range getListenerSettings(
+		address,
+		session,
+	) {
+		servers = append(
+			servers,
+			createServer(
+				session,
+				setting,
+				handler,
+			),
+		)
 	}
-	return &http.Server{
-		Addr:      address,
-		TLSConfig: tlsConfig,
-		Handler: session.customization.WrapHandler(
-			router,
-		),
-	}, https
+	return servers
 }
 
 func listenAndServe(
 	session *session,
-	server *http.Server,
-	serveHTTPS bool,
+	hosted *hostedServer,
 ) error {
-	var listener = session.customization.Listener()
+	logAppRoot(
+		session,
+		LogLevelInfo,
+		"server",
+		"listenAndServe",
+		"Listener [%v] serving on [%v] (HTTPS: %v)",
+		hosted.setting.Name,
+		getListenerAddress(hosted.setting),
+		hosted.https,
+	)
+	var listener = hosted.setting.Listener
 	if listener == nil {
-		if serveHTTPS {
-			return server.ListenAndServeTLS("", "")
+		if hosted.https {
+			return hosted.server.ListenAndServeTLS("", "")
 		}
-		return server.ListenAndServe()
+		return hosted.server.ListenAndServe()
 	} else {
-		if serveHTTPS {
-			return server.ServeTLS(listener, "", "")
+		if hosted.https {
+			return hosted.server.ServeTLS(listener, "", "")
 		}
-		return server.Serve(listener)
+		return hosted.server.Serve(listener)
+	}
+}
+
+// drainServer waits for the customized drain delay before shutting down, so that load balancers observe the failing readiness and stop sending new requests
+func drainServer(session *session) {
+	var drainDelay = session.customization.ShutdownDrainDelay()
+	if drainDelay <= 0 {
+		return
 	}
+	logAppRoot(
+		session,
+		LogLevelInfo,
+		"server",
+		"drainServer",
+		"Draining for %v before shutdown",
+		drainDelay,
+	)
+	time.Sleep(drainDelay)
 }
 
 func shutdownServer(
@@ -125,21 +188,23 @@ func shutdownServer(
 
 func evaluateServerErrors(
 	session *session,
-	hostError error,
+	hostErrors []error,
 	shutdownError error,
 ) bool {
 	var result = true
-	if hostError != nil &&
-		hostError != http.ErrServerClosed {
-		logAppRoot(
-			session,
-			LogLevelError,
-			"server",
-			"runServer",
-			"Host error found: %+v",
-			hostError,
-		)
-		result = false
+	for _, hostError := range hostErrors {
+		if hostError != nil &&
+			hostError != http.ErrServerClosed {
+			logAppRoot(
+				session,
+				LogLevelError,
+				"server",
+				"runServer",
+				"Host error found: %+v",
+				hostError,
+			)
+			result = false
+		}
 	}
 	if shutdownError != nil &&
 		shutdownError != http.ErrServerClosed {
@@ -157,41 +222,39 @@ func evaluateServerErrors(
 }
 
 func runServer(
-	address string,
 	session *session,
-	router chi.Router,
+	servers []*hostedServer,
 	shutdownSignal chan os.Signal,
-	started *bool,
+	started *atomic.Bool,
 ) bool {
-	var server, https = createServer(
-		address,
-		session,
-		router,
-	)
-
 	signal.Notify(
 		shutdownSignal,
 		os.Interrupt,
 		syscall.SIGTERM,
 	)
 
-	*started = true
+	started.Store(true)
 
-	var hostError error
-	go func() {
-		hostError = listenAndServe(
-			session,
-			server,
-			https,
-		)
-		haltServer(
-			shutdownSignal,
-		)
-	}()
+	// any listener stopping serving halts all the others, as they share one lifecycle
+	var hostErrors = make([]error, len(servers))
+	var hostStopped = make(chan bool, len(servers))
+	var hostGroup sync.WaitGroup
+	for index, hosted := range servers {
+		hostGroup.Go(func() {
+			hostErrors[index] = listenAndServe(
+				session,
+				hosted,
+			)
+			hostStopped <- true
+		})
+	}
 
-	<-shutdownSignal
+	select {
+	case <-shutdownSignal:
+	case <-hostStopped:
+	}
 
-	*started = false
+	started.Store(false)
 
 	logAppRoot(
 		session,
@@ -201,20 +264,16 @@ func runServer(
 		"Interrupt signal received: Terminating server",
 	)
 
-	var runtimeContext, cancelCallback = context.WithTimeout(
-		context.Background(),
-		session.customization.GraceShutdownWaitTime(),
+	var shutdownError = shutdownInPhases(
+		session,
+		servers,
 	)
-	defer cancelCallback()
 
-	var shutdownError = shutdownServer(
-		runtimeContext,
-		server,
-	)
+	hostGroup.Wait()
 
 	return evaluateServerErrors(
 		session,
-		hostError,
+		hostErrors,
 		shutdownError,
 	)
 }
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -23,12 +24,16 @@ import (
 func TestHostServer_ErrorRegisterRoutes(t *testing.T) {
 	// arrange
 	var dummyAddress = "some address"
+	var dummyCustomization = &DefaultCustomization{}
 	var dummyApplication = &application{
 		address: dummyAddress,
 	}
-	var dummySession = &session{id: uuid.New()}
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: dummyCustomization,
+	}
 	var dummyShutdownSignal = make(chan os.Signal)
-	var dummyStarted = rand.IntN(100) > 50
+	var dummyStarted atomic.Bool
 	type router struct {
 		chi.Router
 	}
@@ -39,7 +44,8 @@ func TestHostServer_ErrorRegisterRoutes(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(instantiateRouter).Expects(dummyApplication, dummySession).Returns(dummyRouter, dummyError).Once()
+	m.Mock((*DefaultCustomization).AdminServer).Expects(dummyCustomization).Returns(nil).Once()
+	m.Mock(instantiateRouter).Expects(dummyApplication, dummySession, true).Returns(dummyRouter, dummyError).Once()
 
 	// SUT + act
 	var err = hostServer(
@@ -53,30 +59,35 @@ func TestHostServer_ErrorRegisterRoutes(t *testing.T) {
 	assert.Equal(t, dummyError, err)
 }
 
-func TestHostServer_RunServerFailure(t *testing.T) {
+func TestHostServer_ErrorAdminServer(t *testing.T) {
 	// arrange
 	var dummyAddress = "some address"
+	var dummyCustomization = &DefaultCustomization{}
 	var dummyApplication = &application{
 		address: dummyAddress,
 	}
-	var dummySession = &session{id: uuid.New()}
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: dummyCustomization,
+	}
 	var dummyShutdownSignal = make(chan os.Signal)
-	var dummyStarted = rand.IntN(100) > 50
+	var dummyStarted atomic.Bool
 	type router struct {
 		chi.Router
 	}
 	var dummyRouter = &router{}
-	var dummyAppError = &appError{Message: "some error message"}
+	var dummyAdminSetting = &AdminSetting{}
+	var dummyServers = []*hostedServer{{}}
+	var dummyError = errors.New("some error")
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(instantiateRouter).Expects(dummyApplication, dummySession).Returns(dummyRouter, nil).Once()
-	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "hostServer", "Targeting address [%v]", dummyAddress).Returns().Once()
-	m.Mock(logAppRoot).Expects(dummySession, LogLevelWarn, "server", "hostServer", "Server terminated").Returns().Once()
-	m.Mock(runServer).Expects(dummyAddress, dummySession, dummyRouter, dummyShutdownSignal, &dummyStarted).Returns(false).Once()
-	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageHostServer).Returns(dummyAppError).Once()
+	m.Mock((*DefaultCustomization).AdminServer).Expects(dummyCustomization).Returns(dummyAdminSetting).Once()
+	m.Mock(instantiateRouter).Expects(dummyApplication, dummySession, false).Returns(dummyRouter, nil).Once()
+	m.Mock(createServers).Expects(dummyAddress, dummySession, dummyRouter).Returns(dummyServers).Once()
+	m.Mock(createAdminServer).Expects(dummyApplication, dummySession, dummyAdminSetting, dummyRouter).Returns(nil, dummyError).Once()
 
 	// SUT + act
 	var err = hostServer(
@@ -87,31 +98,40 @@ func TestHostServer_RunServerFailure(t *testing.T) {
 	)
 
 	// assert
-	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, dummyError, err)
 }
 
-func TestHostServer_RunServerSuccess(t *testing.T) {
+func TestHostServer_RunServerFailure(t *testing.T) {
 	// arrange
 	var dummyAddress = "some address"
+	var dummyCustomization = &DefaultCustomization{}
 	var dummyApplication = &application{
 		address: dummyAddress,
 	}
-	var dummySession = &session{id: uuid.New()}
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: dummyCustomization,
+	}
 	var dummyShutdownSignal = make(chan os.Signal)
-	var dummyStarted = rand.IntN(100) > 50
+	var dummyStarted atomic.Bool
 	type router struct {
 		chi.Router
 	}
 	var dummyRouter = &router{}
+	var dummyServers = []*hostedServer{{}}
+	var dummyAppError = &appError{Message: "some error message"}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(instantiateRouter).Expects(dummyApplication, dummySession).Returns(dummyRouter, nil).Once()
+	m.Mock((*DefaultCustomization).AdminServer).Expects(dummyCustomization).Returns(nil).Once()
+	m.Mock(instantiateRouter).Expects(dummyApplication, dummySession, true).Returns(dummyRouter, nil).Once()
+	m.Mock(createServers).Expects(dummyAddress, dummySession, dummyRouter).Returns(dummyServers).Once()
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "hostServer", "Targeting address [%v]", dummyAddress).Returns().Once()
-	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "hostServer", "Server closed").Returns().Once()
-	m.Mock(runServer).Expects(dummyAddress, dummySession, dummyRouter, dummyShutdownSignal, &dummyStarted).Returns(true).Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelWarn, "server", "hostServer", "Server terminated").Returns().Once()
+	m.Mock(runServer).Expects(dummySession, dummyServers, dummyShutdownSignal, &dummyStarted).Returns(false).Once()
+	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageHostServer).Returns(dummyAppError).Once()
 
 	// SUT + act
 	var err = hostServer(
@@ -122,99 +142,96 @@ func TestHostServer_RunServerSuccess(t *testing.T) {
 	)
 
 	// assert
-	assert.NoError(t, err)
+	assert.Equal(t, dummyAppError, err)
 }
 
-func TestCreateServer_NoServerCert(t *testing.T) {
+func TestHostServer_RunServerSuccess(t *testing.T) {
 	// arrange
 	var dummyAddress = "some address"
 	var dummyCustomization = &DefaultCustomization{}
+	var dummyApplication = &application{
+		address: dummyAddress,
+	}
 	var dummySession = &session{
 		id:            uuid.New(),
 		customization: dummyCustomization,
 	}
+	var dummyShutdownSignal = make(chan os.Signal)
+	var dummyStarted atomic.Bool
 	type router struct {
 		chi.Router
 	}
 	var dummyRouter = &router{}
-	var dummyServerCert *tls.Certificate
+	var dummyAdminSetting = &AdminSetting{}
+	var dummyServer = &hostedServer{}
+	var dummyAdminServer = &hostedServer{}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock((*DefaultCustomization).ServerCert).Expects(dummyCustomization).Returns(dummyServerCert).Once()
-	m.Mock((*DefaultCustomization).WrapHandler).Expects(dummyCustomization, dummyRouter).Returns(dummyRouter).Once()
+	m.Mock((*DefaultCustomization).AdminServer).Expects(dummyCustomization).Returns(dummyAdminSetting).Once()
+	m.Mock(instantiateRouter).Expects(dummyApplication, dummySession, false).Returns(dummyRouter, nil).Once()
+	m.Mock(createServers).Expects(dummyAddress, dummySession, dummyRouter).Returns([]*hostedServer{dummyServer}).Once()
+	m.Mock(createAdminServer).Expects(dummyApplication, dummySession, dummyAdminSetting, dummyRouter).Returns(dummyAdminServer, nil).Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "hostServer", "Targeting address [%v]", dummyAddress).Returns().Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "hostServer", "Server closed").Returns().Once()
+	m.Mock(runServer).Expects(dummySession, []*hostedServer{dummyServer, dummyAdminServer}, dummyShutdownSignal, &dummyStarted).Returns(true).Once()
 
 	// SUT + act
-	var server, https = createServer(
-		dummyAddress,
+	var err = hostServer(
+		dummyApplication,
 		dummySession,
-		dummyRouter,
+		dummyShutdownSignal,
+		&dummyStarted,
 	)
 
 	// assert
-	assert.NotNil(t, server)
-	assert.Equal(t, dummyAddress, server.Addr)
-	assert.NotNil(t, server.TLSConfig)
-	assert.Empty(t, server.TLSConfig.Certificates)
-	assert.Equal(t, tls.NoClientCert, server.TLSConfig.ClientAuth)
-	assert.Nil(t, server.TLSConfig.ClientCAs)
-	assert.Empty(t, server.TLSConfig.CipherSuites)
-	assert.Equal(t, uint16(tls.VersionTLS12), server.TLSConfig.MinVersion)
-	assert.Zero(t, server.WriteTimeout)
-	assert.Zero(t, server.ReadTimeout)
-	assert.Zero(t, server.IdleTimeout)
-	assert.False(t, https)
+	assert.NoError(t, err)
 }
 
-func TestCreateServer_WithServerCert_NoCaCertPool(t *testing.T) {
+func TestCreateServer(t *testing.T) {
 	// arrange
-	var dummyAddress = "some address"
-	var dummyCustomization = &DefaultCustomization{}
-	var dummySession = &session{
-		id:            uuid.New(),
-		customization: dummyCustomization,
-	}
-	type router struct {
-		chi.Router
+	var dummySession = &session{id: uuid.New()}
+	var dummySetting = ListenerSetting{
+		Name:           "some name",
+		Address:        "some address",
+		ServerCert:     &tls.Certificate{},
+		CaCertPool:     &x509.CertPool{},
+		ClientAuthMode: ClientAuthVerifyIfGiven,
 	}
-	var dummyRouter = &router{}
-	var dummyServerCert = &tls.Certificate{}
-	var dummyCaCertPool *x509.CertPool
+	var dummyHandler = http.NotFoundHandler()
+	var dummyProvider = &staticCertificateProvider{}
+	var dummyTLSConfig = &tls.Config{}
+	var dummyHTTPS = rand.IntN(100) > 50
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock((*DefaultCustomization).ServerCert).Expects(dummyCustomization).Returns(dummyServerCert).Once()
-	m.Mock((*DefaultCustomization).CaCertPool).Expects(dummyCustomization).Returns(dummyCaCertPool).Once()
-	m.Mock((*DefaultCustomization).WrapHandler).Expects(dummyCustomization, dummyRouter).Returns(dummyRouter).Once()
+	m.Mock(getCertificateProvider).Expects(dummySetting).Returns(dummyProvider).Once()
+	m.Mock(createTLSConfig).Expects(dummySession, dummyProvider, ClientAuthVerifyIfGiven).Returns(dummyTLSConfig, dummyHTTPS).Once()
 
 	// SUT + act
-	var server, https = createServer(
-		dummyAddress,
+	var result = createServer(
 		dummySession,
-		dummyRouter,
+		dummySetting,
+		dummyHandler,
 	)
 
 	// assert
-	assert.NotNil(t, server)
-	assert.Equal(t, dummyAddress, server.Addr)
-	assert.NotNil(t, server.TLSConfig)
-	assert.Equal(t, 1, len(server.TLSConfig.Certificates))
-	assert.Equal(t, *dummyServerCert, server.TLSConfig.Certificates[0])
-	assert.Equal(t, tls.RequireAnyClientCert, server.TLSConfig.ClientAuth)
-	assert.Nil(t, server.TLSConfig.ClientCAs)
-	assert.Empty(t, server.TLSConfig.CipherSuites)
-	assert.Equal(t, uint16(tls.VersionTLS12), server.TLSConfig.MinVersion)
-	assert.Zero(t, server.WriteTimeout)
-	assert.Zero(t, server.ReadTimeout)
-	assert.Zero(t, server.IdleTimeout)
-	assert.True(t, https)
+	assert.NotNil(t, result)
+	assert.Equal(t, dummySetting, result.setting)
+	assert.Equal(t, "some address", result.server.Addr)
+	assert.Equal(t, dummyTLSConfig, result.server.TLSConfig)
+	assert.NotNil(t, result.server.Handler)
+	assert.Zero(t, result.server.WriteTimeout)
+	assert.Zero(t, result.server.ReadTimeout)
+	assert.Zero(t, result.server.IdleTimeout)
+	assert.Equal(t, dummyHTTPS, result.https)
 }
 
-func TestCreateServer_WithServerCert_WithCaCertPool(t *testing.T) {
+func TestCreateServers(t *testing.T) {
 	// arrange
 	var dummyAddress = "some address"
 	var dummyCustomization = &DefaultCustomization{}
@@ -226,63 +243,59 @@ func TestCreateServer_WithServerCert_WithCaCertPool(t *testing.T) {
 		chi.Router
 	}
 	var dummyRouter = &router{}
-	var dummyServerCert = &tls.Certificate{}
-	var dummyCaCertPool = &x509.CertPool{}
+	var dummySettings = []ListenerSetting{
+		{Name: "some name 1"},
+		{Name: "some name 2"},
+	}
+	var dummyServer1 = &hostedServer{setting: dummySettings[0]}
+	var dummyServer2 = &hostedServer{setting: dummySettings[1]}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock((*DefaultCustomization).ServerCert).Expects(dummyCustomization).Returns(dummyServerCert).Once()
-	m.Mock((*DefaultCustomization).CaCertPool).Expects(dummyCustomization).Returns(dummyCaCertPool).Once()
 	m.Mock((*DefaultCustomization).WrapHandler).Expects(dummyCustomization, dummyRouter).Returns(dummyRouter).Once()
+	m.Mock(getListenerSettings).Expects(dummyAddress, dummySession).Returns(dummySettings).Once()
+	m.Mock(createServer).Expects(dummySession, dummySettings[0], dummyRouter).Returns(dummyServer1).Once()
+	m.Mock(createServer).Expects(dummySession, dummySettings[1], dummyRouter).Returns(dummyServer2).Once()
 
 	// SUT + act
-	var server, https = createServer(
+	var result = createServers(
 		dummyAddress,
 		dummySession,
 		dummyRouter,
 	)
 
 	// assert
-	assert.NotNil(t, server)
-	assert.Equal(t, dummyAddress, server.Addr)
-	assert.NotNil(t, server.TLSConfig)
-	assert.Equal(t, 1, len(server.TLSConfig.Certificates))
-	assert.Equal(t, *dummyServerCert, server.TLSConfig.Certificates[0])
-	assert.Equal(t, tls.RequireAndVerifyClientCert, server.TLSConfig.ClientAuth)
-	assert.Equal(t, dummyCaCertPool, server.TLSConfig.ClientCAs)
-	assert.Empty(t, server.TLSConfig.CipherSuites)
-	assert.Equal(t, uint16(tls.VersionTLS12), server.TLSConfig.MinVersion)
-	assert.Zero(t, server.WriteTimeout)
-	assert.Zero(t, server.ReadTimeout)
-	assert.Zero(t, server.IdleTimeout)
-	assert.True(t, https)
+	assert.Equal(t, []*hostedServer{dummyServer1, dummyServer2}, result)
 }
 
 func TestListenAndServe_NoListener_HTTPS(t *testing.T) {
 	// arrange
-	var dummyCustomization = &DefaultCustomization{}
-	var dummySession = &session{
-		id:            uuid.New(),
-		customization: dummyCustomization,
-	}
+	var dummySession = &session{id: uuid.New()}
 	var dummyServer = &http.Server{}
-	var dummyServeHTTPS = true
+	var dummyHosted = &hostedServer{
+		setting: ListenerSetting{
+			Name:    "some name",
+			Address: "some address",
+		},
+		server: dummyServer,
+		https:  true,
+	}
 	var dummyError = errors.New("some error")
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock((*DefaultCustomization).Listener).Expects(dummyCustomization).Returns(nil).Once()
+	m.Mock(getListenerAddress).Expects(dummyHosted.setting).Returns("some listener address").Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "listenAndServe", "Listener [%v] serving on [%v] (HTTPS: %v)", "some name", "some listener address", true).Returns().Once()
 	m.Mock((*http.Server).ListenAndServeTLS).Expects(dummyServer, "", "").Returns(dummyError).Once()
 
 	// SUT + act
 	var err = listenAndServe(
 		dummySession,
-		dummyServer,
-		dummyServeHTTPS,
+		dummyHosted,
 	)
 
 	// assert
@@ -291,27 +304,30 @@ func TestListenAndServe_NoListener_HTTPS(t *testing.T) {
 
 func TestListenAndServe_NoListener_HTTP(t *testing.T) {
 	// arrange
-	var dummyCustomization = &DefaultCustomization{}
-	var dummySession = &session{
-		id:            uuid.New(),
-		customization: dummyCustomization,
-	}
+	var dummySession = &session{id: uuid.New()}
 	var dummyServer = &http.Server{}
-	var dummyServeHTTPS = false
+	var dummyHosted = &hostedServer{
+		setting: ListenerSetting{
+			Name:    "some name",
+			Address: "some address",
+		},
+		server: dummyServer,
+		https:  false,
+	}
 	var dummyError = errors.New("some error")
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock((*DefaultCustomization).Listener).Expects(dummyCustomization).Returns(nil).Once()
+	m.Mock(getListenerAddress).Expects(dummyHosted.setting).Returns("some listener address").Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "listenAndServe", "Listener [%v] serving on [%v] (HTTPS: %v)", "some name", "some listener address", false).Returns().Once()
 	m.Mock((*http.Server).ListenAndServe).Expects(dummyServer).Returns(dummyError).Once()
 
 	// SUT + act
 	var err = listenAndServe(
 		dummySession,
-		dummyServer,
-		dummyServeHTTPS,
+		dummyHosted,
 	)
 
 	// assert
@@ -320,28 +336,32 @@ func TestListenAndServe_NoListener_HTTP(t *testing.T) {
 
 func TestListenAndServe_WithListener_HTTPS(t *testing.T) {
 	// arrange
-	var dummyCustomization = &DefaultCustomization{}
-	var dummySession = &session{
-		id:            uuid.New(),
-		customization: dummyCustomization,
-	}
+	var dummySession = &session{id: uuid.New()}
 	var dummyServer = &http.Server{}
-	var dummyServeHTTPS = true
 	var dummyListener = &net.UnixListener{}
+	var dummyHosted = &hostedServer{
+		setting: ListenerSetting{
+			Name:     "some name",
+			Address:  "some address",
+			Listener: dummyListener,
+		},
+		server: dummyServer,
+		https:  true,
+	}
 	var dummyError = errors.New("some error")
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock((*DefaultCustomization).Listener).Expects(dummyCustomization).Returns(dummyListener).Once()
+	m.Mock(getListenerAddress).Expects(dummyHosted.setting).Returns("some listener address").Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "listenAndServe", "Listener [%v] serving on [%v] (HTTPS: %v)", "some name", "some listener address", true).Returns().Once()
 	m.Mock((*http.Server).ServeTLS).Expects(dummyServer, dummyListener, "", "").Returns(dummyError).Once()
 
 	// SUT + act
 	var err = listenAndServe(
 		dummySession,
-		dummyServer,
-		dummyServeHTTPS,
+		dummyHosted,
 	)
 
 	// assert
@@ -350,34 +370,79 @@ func TestListenAndServe_WithListener_HTTPS(t *testing.T) {
 
 func TestListenAndServe_WithListener_HTTP(t *testing.T) {
 	// arrange
-	var dummyCustomization = &DefaultCustomization{}
-	var dummySession = &session{
-		id:            uuid.New(),
-		customization: dummyCustomization,
-	}
+	var dummySession = &session{id: uuid.New()}
 	var dummyServer = &http.Server{}
-	var dummyServeHTTPS = false
 	var dummyListener = &net.UnixListener{}
+	var dummyHosted = &hostedServer{
+		setting: ListenerSetting{
+			Name:     "some name",
+			Address:  "some address",
+			Listener: dummyListener,
+		},
+		server: dummyServer,
+		https:  false,
+	}
 	var dummyError = errors.New("some error")
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock((*DefaultCustomization).Listener).Expects(dummyCustomization).Returns(dummyListener).Once()
+	m.Mock(getListenerAddress).Expects(dummyHosted.setting).Returns("some listener address").Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "listenAndServe", "Listener [%v] serving on [%v] (HTTPS: %v)", "some name", "some listener address", false).Returns().Once()
 	m.Mock((*http.Server).Serve).Expects(dummyServer, dummyListener).Returns(dummyError).Once()
 
 	// SUT + act
 	var err = listenAndServe(
 		dummySession,
-		dummyServer,
-		dummyServeHTTPS,
+		dummyHosted,
 	)
 
 	// assert
 	assert.Equal(t, dummyError, err)
 }
 
+func TestDrainServer_NoDelay(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).ShutdownDrainDelay).Expects(dummyCustomization).Returns(time.Duration(0)).Once()
+
+	// SUT + act
+	drainServer(
+		dummySession,
+	)
+}
+
+func TestDrainServer_WithDelay(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyDrainDelay = time.Duration(rand.IntN(100)+1) * time.Second
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).ShutdownDrainDelay).Expects(dummyCustomization).Returns(dummyDrainDelay).Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "drainServer", "Draining for %v before shutdown", dummyDrainDelay).Returns().Once()
+	m.Mock(time.Sleep).Expects(dummyDrainDelay).Returns().Once()
+
+	// SUT + act
+	drainServer(
+		dummySession,
+	)
+}
+
 func TestShutdownServer(t *testing.T) {
 	// arrange
 	var dummyContext = context.TODO()
@@ -396,13 +461,13 @@ func TestShutdownServer(t *testing.T) {
 func TestEvaluateServerErrors_NoErrors(t *testing.T) {
 	// arrange
 	var dummySession = &session{id: uuid.New()}
-	var dummyHostError error
+	var dummyHostErrors = []error{nil, nil}
 	var dummyShutDownError error
 
 	// SUT + act
 	var result = evaluateServerErrors(
 		dummySession,
-		dummyHostError,
+		dummyHostErrors,
 		dummyShutDownError,
 	)
 
@@ -413,13 +478,13 @@ func TestEvaluateServerErrors_NoErrors(t *testing.T) {
 func TestEvaluateServerErrors_FilteredErrors(t *testing.T) {
 	// arrange
 	var dummySession = &session{id: uuid.New()}
-	var dummyHostError = http.ErrServerClosed
+	var dummyHostErrors = []error{http.ErrServerClosed, http.ErrServerClosed}
 	var dummyShutDownError = http.ErrServerClosed
 
 	// SUT + act
 	var result = evaluateServerErrors(
 		dummySession,
-		dummyHostError,
+		dummyHostErrors,
 		dummyShutDownError,
 	)
 
@@ -431,6 +496,7 @@ func TestEvaluateServerErrors_OtherErrors(t *testing.T) {
 	// arrange
 	var dummySession = &session{id: uuid.New()}
 	var dummyHostError = errors.New("some host error")
+	var dummyHostErrors = []error{http.ErrServerClosed, dummyHostError}
 	var dummyShutDownError = errors.New("some shutdown error")
 
 	// mock
@@ -443,7 +509,7 @@ func TestEvaluateServerErrors_OtherErrors(t *testing.T) {
 	// SUT + act
 	var result = evaluateServerErrors(
 		dummySession,
-		dummyHostError,
+		dummyHostErrors,
 		dummyShutDownError,
 	)
 
@@ -451,27 +517,13 @@ func TestEvaluateServerErrors_OtherErrors(t *testing.T) {
 	assert.False(t, result)
 }
 
-func TestRunServer_HappyPath(t *testing.T) {
+func TestRunServer_HostStopped(t *testing.T) {
 	// arrange
-	var dummyAddress = "some address"
-	var dummyCustomization = &DefaultCustomization{}
-	var dummySession = &session{
-		id:            uuid.New(),
-		customization: dummyCustomization,
-	}
-	type router struct {
-		chi.Router
-	}
-	var dummyRouter = &router{}
+	var dummySession = &session{id: uuid.New()}
 	var dummyShutdownSignal = make(chan os.Signal)
-	var dummyStarted = false
-	var dummyServer = &http.Server{}
-	var dummyHTTPS = rand.IntN(100) > 50
+	var dummyStarted atomic.Bool
+	var dummyServers = []*hostedServer{{}, {}}
 	var dummyHostError = errors.New("some host error message")
-	var dummyBackgroundContext = context.Background()
-	var dummyRuntimeContext = context.TODO()
-	var dummyCallback = func() {}
-	var dummyGraceShutdownWaitTime = time.Duration(rand.IntN(100)) * time.Second
 	var dummyShutDownError = errors.New("some shut down error message")
 	var dummyResult = rand.IntN(100) > 50
 
@@ -479,31 +531,57 @@ func TestRunServer_HappyPath(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(createServer).Expects(dummyAddress, dummySession, dummyRouter).Returns(dummyServer, dummyHTTPS).Once()
 	m.Mock(signal.Notify).Expects(gomocker.Anything(), os.Interrupt, syscall.SIGTERM).Returns().Once()
-	m.Mock(listenAndServe).Expects(dummySession, dummyServer, dummyHTTPS).Returns(dummyHostError).Once()
-	m.Mock(haltServer).Expects(gomocker.Anything()).Returns().SideEffects(gomocker.GeneralSideEffect(
-		0, func() { dummyShutdownSignal <- os.Interrupt })).Once()
+	m.Mock(listenAndServe).Expects(dummySession, gomocker.Anything()).Returns(dummyHostError).Twice()
 	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "runServer", "Interrupt signal received: Terminating server").Returns().Once()
-	m.Mock(context.Background).Expects().Returns(dummyBackgroundContext).Once()
-	m.Mock((*DefaultCustomization).GraceShutdownWaitTime).Expects(dummyCustomization).Returns(dummyGraceShutdownWaitTime).Once()
-	m.Mock(context.WithTimeout).Expects(dummyBackgroundContext, dummyGraceShutdownWaitTime).Returns(dummyRuntimeContext, dummyCallback).Once()
-	m.Mock(shutdownServer).Expects(dummyRuntimeContext, dummyServer).Returns(dummyShutDownError).Once()
-	m.Mock(evaluateServerErrors).Expects(dummySession, dummyHostError, dummyShutDownError).Returns(dummyResult).Once()
-	m.Mock(dummyCallback).Expects().Returns().Once()
+	m.Mock(shutdownInPhases).Expects(dummySession, dummyServers).Returns(dummyShutDownError).Once()
+	m.Mock(evaluateServerErrors).Expects(dummySession, []error{dummyHostError, dummyHostError}, dummyShutDownError).Returns(dummyResult).Once()
 
 	// SUT + act
 	var result = runServer(
-		dummyAddress,
 		dummySession,
-		dummyRouter,
+		dummyServers,
+		dummyShutdownSignal,
+		&dummyStarted,
+	)
+
+	// assert
+	assert.Equal(t, dummyResult, result)
+	assert.False(t, dummyStarted.Load())
+}
+
+func TestRunServer_ShutdownSignal(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyShutdownSignal = make(chan os.Signal)
+	var dummyStarted atomic.Bool
+	var dummyServers = []*hostedServer{{}}
+	var dummyReleased = make(chan bool)
+	var dummyResult = rand.IntN(100) > 50
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(signal.Notify).Expects(gomocker.Anything(), os.Interrupt, syscall.SIGTERM).Returns().Once()
+	m.Mock(listenAndServe).Expects(dummySession, dummyServers[0]).Returns(http.ErrServerClosed).SideEffects(gomocker.GeneralSideEffect(
+		0, func() { dummyShutdownSignal <- os.Interrupt; <-dummyReleased })).Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "server", "runServer", "Interrupt signal received: Terminating server").Returns().Once()
+	m.Mock(shutdownInPhases).Expects(dummySession, dummyServers).Returns(nil).SideEffects(gomocker.GeneralSideEffect(
+		0, func() { close(dummyReleased) })).Once()
+	m.Mock(evaluateServerErrors).Expects(dummySession, []error{http.ErrServerClosed}, nil).Returns(dummyResult).Once()
+
+	// SUT + act
+	var result = runServer(
+		dummySession,
+		dummyServers,
 		dummyShutdownSignal,
 		&dummyStarted,
 	)
 
 	// assert
 	assert.Equal(t, dummyResult, result)
-	assert.False(t, dummyStarted)
+	assert.False(t, dummyStarted.Load())
 }
 
 func TestHaltServer(t *testing.T) {
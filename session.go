@@ -1,15 +1,20 @@
 package webserver
 
 import (
+	"crypto/x509"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/textproto"
 	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -36,6 +41,12 @@ type SessionMeta interface {
 
 	// GetCustomization returns the customization defined for the web application
 	GetCustomization() Customization
+
+	// GetTraceID returns the W3C trace ID of the session, continued from the traceparent header of the request, or newly generated if absent or invalid
+	GetTraceID() string
+
+	// GetSpanID returns the W3C span ID of the session, newly generated for each session as a child of the span given by the traceparent header of the request
+	GetSpanID() string
 }
 
 // SessionHTTP is a subset of Session interface, containing only HTTP request & response related methods
@@ -55,6 +66,15 @@ type SessionHTTPRequest interface {
 	// GetRoutePattern returns the defined route pattern matched for the current HTTP request
 	GetRoutePattern() string
 
+	// GetClientCertificate returns the client certificate verified against the CA cert pool during the TLS handshake of the current HTTP request, or nil if not given or not verified
+	GetClientCertificate() *x509.Certificate
+
+	// GetClientCommonName returns the subject common name of the verified client certificate, or empty if not given or not verified
+	GetClientCommonName() string
+
+	// GetClientSANs returns the subject alternative names (DNS names, email addresses, IP addresses and URIs) of the verified client certificate, or nil if not given or not verified
+	GetClientSANs() []string
+
 	// GetRequestBody loads HTTP request body associated to session and unmarshals the content JSON to given data template
 	GetRequestBody(dataTemplate any) error
 
@@ -72,6 +92,9 @@ type SessionHTTPRequest interface {
 
 	// GetRequestHeader loads HTTP request single header string associated to session for given name and unmarshals the content to given data template
 	GetRequestHeader(name string, index int, dataTemplate any) error
+
+	// BindRequest loads HTTP request parameters, queries, headers and body associated to session into the fields of given data template (must be a pointer to struct) tagged by "path", "query", "header" and "body" respectively
+	BindRequest(dataTemplate any) error
 }
 
 // SessionHTTPResponse is a subset of SessionHTTP interface, containing only HTTP response related methods
@@ -111,23 +134,37 @@ type SessionLogging interface {
 
 	// LogMethodExit sends a logging entry of MethodExit log type for the given session associated to the session ID
 	LogMethodExit()
+
+	// AddLogAttributes adds the given attributes to all subsequent logging entries of the given session associated to the session ID, when logged through the structured logging backend
+	AddLogAttributes(attributes ...slog.Attr)
 }
 
 // SessionWebcall is a subset of Session interface, containing only webcall related methods
 type SessionWebcall interface {
 	// CreateWebcallRequest generates a webcall request object to the targeted external web service for the given session associated to the session ID
 	CreateWebcallRequest(method string, url string, payload string, sendClientCert bool) WebRequest
+
+	// CreateStreamingWebcallRequest generates a webcall request object to the targeted external web service for the given session associated to the session ID, streaming the payload from the given reader instead of holding it in memory; the payload cannot be replayed, so the request is never retried and can only be processed once
+	CreateStreamingWebcallRequest(method string, url string, payload io.Reader, sendClientCert bool) WebRequest
 }
 
 type session struct {
-	id             uuid.UUID
-	name           string
-	method         string
-	pattern        string
-	request        *http.Request
-	responseWriter http.ResponseWriter
-	attachment     map[string]any
-	customization  Customization
+	id               uuid.UUID
+	name             string
+	method           string
+	pattern          string
+	request          *http.Request
+	responseWriter   http.ResponseWriter
+	attachment       map[string]any
+	customization    Customization
+	startTime        time.Time
+	statusCode       int
+	logAttributes    []slog.Attr
+	logFilter        *LogFilter
+	logBodyUnsampled bool
+	trace            traceContext
+	span             trace.Span
+	cleanups         []func()
 }
 
 // GetID returns the ID of this registered session object
@@ -151,6 +188,22 @@ func (session *session) GetCustomization() Customization {
 	return session.customization
 }
 
+// GetTraceID returns the W3C trace ID of the session, continued from the traceparent header of the request, or newly generated if absent or invalid
+func (session *session) GetTraceID() string {
+	if session == nil {
+		return ""
+	}
+	return session.trace.traceID
+}
+
+// GetSpanID returns the W3C span ID of the session, newly generated for each session as a child of the span given by the traceparent header of the request
+func (session *session) GetSpanID() string {
+	if session == nil {
+		return ""
+	}
+	return session.trace.spanID
+}
+
 // GetRequest returns the HTTP request object from session object for given session ID
 func (session *session) GetRequest() *http.Request {
 	if session == nil ||
@@ -179,6 +232,45 @@ func (session *session) GetRoutePattern() string {
 	return session.pattern
 }
 
+// GetClientCertificate returns the client certificate verified against the CA cert pool during the TLS handshake of the current HTTP request, or nil if not given or not verified
+func (session *session) GetClientCertificate() *x509.Certificate {
+	if session == nil ||
+		session.request == nil ||
+		session.request.TLS == nil ||
+		len(session.request.TLS.VerifiedChains) == 0 ||
+		len(session.request.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return session.request.TLS.VerifiedChains[0][0]
+}
+
+// GetClientCommonName returns the subject common name of the verified client certificate, or empty if not given or not verified
+func (session *session) GetClientCommonName() string {
+	var clientCert = session.GetClientCertificate()
+	if clientCert == nil {
+		return ""
+	}
+	return clientCert.Subject.CommonName
+}
+
+// GetClientSANs returns the subject alternative names (DNS names, email addresses, IP addresses and URIs) of the verified client certificate, or nil if not given or not verified
+func (session *session) GetClientSANs() []string {
+	var clientCert = session.GetClientCertificate()
+	if clientCert == nil {
+		return nil
+	}
+	var sans = []string{}
+	sans = append(sans, clientCert.DNSNames...)
+	sans = append(sans, clientCert.EmailAddresses...)
+	for _, ipAddress := range clientCert.IPAddresses {
+		sans = append(sans, ipAddress.String())
+	}
+	for _, uri := range clientCert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
 // GetRequestBodyFromSession is a sugar-function to retrieve request body as an object via generics
 func GetRequestBodyFromSession[T any](session Session) (*T, error) {
 	var result T
@@ -186,7 +278,7 @@ func GetRequestBodyFromSession[T any](session Session) (*T, error) {
 	return &result, err
 }
 
-// GetRequestBody loads HTTP request body associated to session and unmarshals the content JSON to given data template
+// GetRequestBody loads HTTP request body associated to session and unmarshals the content to given data template, with the decoder picked by the Content-Type header of the request, or as JSON if none matches
 func (session *session) GetRequestBody(dataTemplate any) error {
 	if session == nil {
 		return newAppError(
@@ -211,7 +303,9 @@ func (session *session) GetRequestBody(dataTemplate any) error {
 		"%s",
 		requestBody,
 	)
-	var unmarshalError = tryUnmarshal(
+	var unmarshalError = decodeRequestBody(
+		session,
+		httpRequest,
 		requestBody,
 		dataTemplate,
 	)
@@ -229,7 +323,11 @@ func (session *session) GetRequestBody(dataTemplate any) error {
 			unmarshalError,
 		)
 	}
-	return nil
+	return validateRequestData(
+		session,
+		"Body",
+		dataTemplate,
+	)
 }
 
 // GetRequestParameterFromSession is a sugar-function to retrieve request parameter as an object via generics
@@ -280,7 +378,11 @@ func (session *session) GetRequestParameter(name string, dataTemplate any) error
 			unmarshalError,
 		)
 	}
-	return nil
+	return validateRequestData(
+		session,
+		"Parameter",
+		dataTemplate,
+	)
 }
 
 func getAllQueries(session *session, name string) []string {
@@ -365,7 +467,11 @@ func (session *session) GetRequestQueries(name string, dataTemplate any) error {
 		}
 		vTemplate.Set(reflect.Append(vTemplate, vItem.Elem()))
 	}
-	return nil
+	return validateRequestData(
+		session,
+		"Query",
+		dataTemplate,
+	)
 }
 
 // GetRequestQueryFromSession is a sugar-function to retrieve request query as an object via generics
@@ -419,7 +525,11 @@ func (session *session) GetRequestQuery(name string, index int, dataTemplate any
 			unmarshalError,
 		)
 	}
-	return nil
+	return validateRequestData(
+		session,
+		"Query",
+		dataTemplate,
+	)
 }
 
 func getAllHeaders(session *session, name string) []string {
@@ -497,7 +607,11 @@ func (session *session) GetRequestHeaders(name string, dataTemplate any) error {
 		}
 		vTemplate.Set(reflect.Append(vTemplate, vItem.Elem()))
 	}
-	return nil
+	return validateRequestData(
+		session,
+		"Header",
+		dataTemplate,
+	)
 }
 
 // GetRequestHeaderFromSession is a sugar-function to retrieve request header as an object via generics
@@ -551,7 +665,53 @@ func (session *session) GetRequestHeader(name string, index int, dataTemplate an
 			unmarshalError,
 		)
 	}
-	return nil
+	return validateRequestData(
+		session,
+		"Header",
+		dataTemplate,
+	)
+}
+
+// BindRequest is a sugar-function to bind request parameters, queries, headers and body as an object via generics
+func BindRequest[T any](session Session) (*T, error) {
+	var result T
+	var err = session.BindRequest(&result)
+	return &result, err
+}
+
+// BindRequest loads HTTP request parameters, queries, headers and body associated to session into the fields of given data template (must be a pointer to struct) tagged by "path", "query", "header" and "body" respectively
+func (session *session) BindRequest(dataTemplate any) error {
+	if session == nil {
+		return newAppError(
+			errorCodeGeneralFailure,
+			errorMessageSessionNil,
+		)
+	}
+	var vTemplate = reflect.ValueOf(dataTemplate)
+	if vTemplate.Kind() != reflect.Pointer ||
+		vTemplate.IsNil() ||
+		vTemplate.Elem().Kind() != reflect.Struct {
+		return newAppError(
+			errorCodeGeneralFailure,
+			errorMessageDataTemplateInvalid,
+		)
+	}
+	var bindErrors = bindRequestFields(
+		session,
+		vTemplate.Elem(),
+	)
+	if len(bindErrors) > 0 {
+		return newAppError(
+			errorCodeBadRequest,
+			errorMessageRequestBindingFailed,
+			bindErrors...,
+		)
+	}
+	return validateRequestData(
+		session,
+		"Binding",
+		dataTemplate,
+	)
 }
 
 // Attach attaches any value object into the given session associated to the session ID
@@ -621,6 +781,22 @@ func (session *session) GetAttachment(name string, dataTemplate any) bool {
 	return true
 }
 
+// addCleanup registers a function releasing resources held for the request, to be called once the session finishes
+func (session *session) addCleanup(cleanup func()) {
+	session.cleanups = append(
+		session.cleanups,
+		cleanup,
+	)
+}
+
+// releaseSession calls the registered cleanup functions in reverse order of registration
+func releaseSession(session *session) {
+	for index := len(session.cleanups) - 1; index >= 0; index-- {
+		session.cleanups[index]()
+	}
+	session.cleanups = nil
+}
+
 func getMethodName() string {
 	var pc, _, _, ok = runtime.Caller(3)
 	if !ok {
@@ -692,6 +868,17 @@ func (session *session) LogMethodExit() {
 	)
 }
 
+// AddLogAttributes adds the given attributes to all subsequent logging entries of the given session associated to the session ID, when logged through the structured logging backend
+func (session *session) AddLogAttributes(attributes ...slog.Attr) {
+	if session == nil {
+		return
+	}
+	session.logAttributes = append(
+		session.logAttributes,
+		attributes...,
+	)
+}
+
 // CreateWebcallRequest generates a webcall request object to the targeted external web service for the given session associated to the session ID
 func (session *session) CreateWebcallRequest(
 	method string,
@@ -711,5 +898,33 @@ func (session *session) CreateWebcallRequest(
 		sendClientCert,
 		0,
 		[]dataReceiver{},
+		nil,
+		0,
+		nil,
+		nil,
+		false,
+		nil,
+		nil,
+		nil,
+		false,
+	}
+}
+
+// CreateStreamingWebcallRequest generates a webcall request object to the targeted external web service for the given session associated to the session ID, streaming the payload from the given reader instead of holding it in memory; the payload cannot be replayed, so the request is never retried and can only be processed once
+func (session *session) CreateStreamingWebcallRequest(
+	method string,
+	url string,
+	payload io.Reader,
+	sendClientCert bool,
+) WebRequest {
+	var webRequest = session.CreateWebcallRequest(
+		method,
+		url,
+		"",
+		sendClientCert,
+	).(*webRequest)
+	webRequest.payloadStream = &streamCounter{
+		reader: payload,
 	}
+	return webRequest
 }
@@ -1,12 +1,18 @@
 package webserver
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"log/slog"
 	"math/rand/v2"
+	"net"
 	"net/http"
 	"net/textproto"
 	"net/url"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -69,6 +75,64 @@ func TestSessionGetName_ValidSessionObject(t *testing.T) {
 	assert.Equal(t, dummyName, result)
 }
 
+func TestSessionGetTraceID_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetTraceID()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestSessionGetTraceID_ValidSessionObject(t *testing.T) {
+	// arrange
+	var dummyTraceID = "some trace ID"
+
+	// SUT
+	var dummySession = &session{
+		trace: traceContext{
+			traceID: dummyTraceID,
+		},
+	}
+
+	// act
+	var result = dummySession.GetTraceID()
+
+	// assert
+	assert.Equal(t, dummyTraceID, result)
+}
+
+func TestSessionGetSpanID_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetSpanID()
+
+	// assert
+	assert.Zero(t, result)
+}
+
+func TestSessionGetSpanID_ValidSessionObject(t *testing.T) {
+	// arrange
+	var dummySpanID = "some span ID"
+
+	// SUT
+	var dummySession = &session{
+		trace: traceContext{
+			spanID: dummySpanID,
+		},
+	}
+
+	// act
+	var result = dummySession.GetSpanID()
+
+	// assert
+	assert.Equal(t, dummySpanID, result)
+}
+
 func TestSessionGetCustomization_ReturnObject(t *testing.T) {
 	// arrange
 	type customization struct {
@@ -248,6 +312,74 @@ func TestGetRequestBodyFromSession_HappyPath(t *testing.T) {
 	assert.Equal(t, dummyError, err)
 }
 
+func TestSessionGetClientCertificate_NilSessionObject(t *testing.T) {
+	// SUT
+	var dummySession *session
+
+	// act
+	var result = dummySession.GetClientCertificate()
+
+	// assert
+	assert.Nil(t, result)
+}
+
+func TestSessionGetClientCertificate_NotVerified(t *testing.T) {
+	// SUT
+	var dummySession = &session{
+		request: &http.Request{
+			TLS: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{{}},
+			},
+		},
+	}
+
+	// act
+	var result = dummySession.GetClientCertificate()
+	var commonName = dummySession.GetClientCommonName()
+	var sans = dummySession.GetClientSANs()
+
+	// assert
+	assert.Nil(t, result)
+	assert.Empty(t, commonName)
+	assert.Nil(t, sans)
+}
+
+func TestSessionGetClientCertificate_Verified(t *testing.T) {
+	// arrange
+	var dummyURI, _ = url.Parse("spiffe://example.org/service")
+	var dummyClientCert = &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "some common name"},
+		DNSNames:       []string{"service.example.org"},
+		EmailAddresses: []string{"service@example.org"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{dummyURI},
+	}
+
+	// SUT
+	var dummySession = &session{
+		request: &http.Request{
+			TLS: &tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{dummyClientCert, {}}},
+			},
+		},
+	}
+
+	// act
+	var result = dummySession.GetClientCertificate()
+	var commonName = dummySession.GetClientCommonName()
+	var sans = dummySession.GetClientSANs()
+
+	// assert
+	assert.Equal(t, dummyClientCert, result)
+	assert.Equal(t, "some common name", commonName)
+	assert.Equal(t, []string{
+		"service.example.org",
+		"service@example.org",
+		"10.0.0.1",
+		"spiffe://example.org/service",
+	}, sans)
+}
+
 func TestSessionGetRequestBody_NilSession(t *testing.T) {
 	// arrange
 	var dummyDataTemplate int
@@ -322,8 +454,8 @@ func TestSessionGetRequestBody_BodyInvalid(t *testing.T) {
 	m.Mock(getRequestBody).Expects(dummyHTTPRequest).Returns(dummyRequestBody).Once()
 	m.Mock(logEndpointRequest).Expects(dummySession, "Body", "Content", "%s", dummyRequestBody).Returns().Once()
 	m.Mock(logEndpointRequest).Expects(dummySession, "Body", "UnmarshalError", "%+v", dummyError).Returns().Once()
-	m.Mock(tryUnmarshal).Expects(dummyRequestBody, gomocker.Anything()).Returns(dummyError).SideEffects(
-		gomocker.ParamSideEffect(1, 2, func(value *int) { *value = dummyResult })).Once()
+	m.Mock(decodeRequestBody).Expects(dummySession, dummyHTTPRequest, dummyRequestBody, gomocker.Anything()).Returns(dummyError).SideEffects(
+		gomocker.ParamSideEffect(1, 4, func(value *int) { *value = dummyResult })).Once()
 	m.Mock(newAppError).Expects(errorCodeBadRequest, errorMessageRequestBodyInvalid, dummyError).Returns(dummyAppError).Once()
 
 	// act
@@ -354,8 +486,9 @@ func TestSessionGetRequestBody_BodyValid(t *testing.T) {
 	// expect
 	m.Mock(getRequestBody).Expects(dummyHTTPRequest).Returns(dummyRequestBody).Once()
 	m.Mock(logEndpointRequest).Expects(dummySession, "Body", "Content", "%s", dummyRequestBody).Returns().Once()
-	m.Mock(tryUnmarshal).Expects(dummyRequestBody, gomocker.Anything()).Returns(nil).SideEffects(
-		gomocker.ParamSideEffect(1, 2, func(value *int) { *value = dummyResult })).Once()
+	m.Mock(decodeRequestBody).Expects(dummySession, dummyHTTPRequest, dummyRequestBody, gomocker.Anything()).Returns(nil).SideEffects(
+		gomocker.ParamSideEffect(1, 4, func(value *int) { *value = dummyResult })).Once()
+	m.Mock(validateRequestData).Expects(dummySession, "Body", &dummyDataTemplate).Returns(nil).Once()
 
 	// act
 	var err = dummySession.GetRequestBody(
@@ -367,6 +500,39 @@ func TestSessionGetRequestBody_BodyValid(t *testing.T) {
 	assert.Equal(t, dummyResult, dummyDataTemplate)
 }
 
+func TestSessionGetRequestBody_RawStringFromDecodableContentTypes(t *testing.T) {
+	// arrange
+	var dummyBodies = map[string]string{
+		"application/x-www-form-urlencoded": "foo=bar&test=123",
+		"application/xml":                   "<a>hi</a>",
+	}
+
+	for dummyContentType, dummyRequestBody := range dummyBodies {
+		var dummyHTTPRequest, _ = http.NewRequest(
+			http.MethodPost,
+			"http://localhost",
+			strings.NewReader(dummyRequestBody),
+		)
+		dummyHTTPRequest.Header.Set("Content-Type", dummyContentType)
+		var dummyDataTemplate string
+
+		// SUT
+		var dummySession = &session{
+			request:       dummyHTTPRequest,
+			customization: &DefaultCustomization{},
+		}
+
+		// act
+		var err = dummySession.GetRequestBody(
+			&dummyDataTemplate,
+		)
+
+		// assert
+		assert.NoError(t, err, dummyContentType)
+		assert.Equal(t, dummyRequestBody, dummyDataTemplate, dummyContentType)
+	}
+}
+
 func TestGetRequestParameterFromSession_HappyPath(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
@@ -504,6 +670,7 @@ func TestSessionGetRequestParameter_ParameterValid(t *testing.T) {
 	m.Mock(logEndpointRequest).Expects(dummySession, "Parameter", dummyName, "%s", dummyValue).Returns().Once()
 	m.Mock(tryUnmarshal).Expects(dummyValue, gomocker.Anything()).Returns(nil).SideEffects(
 		gomocker.ParamSideEffect(1, 2, func(value *int) { *value = dummyResult })).Once()
+	m.Mock(validateRequestData).Expects(dummySession, "Parameter", &dummyDataTemplate).Returns(nil).Once()
 
 	// act
 	var err = dummySession.GetRequestParameter(
@@ -753,6 +920,7 @@ func TestSessionGetRequestQueries_Success(t *testing.T) {
 	m.Mock(logEndpointRequest).Expects(dummySession, "Query", dummyName, "%s", dummyQueries[0]).Returns().Once()
 	m.Mock(logEndpointRequest).Expects(dummySession, "Query", dummyName, "%s", dummyQueries[1]).Returns().Once()
 	m.Mock(logEndpointRequest).Expects(dummySession, "Query", dummyName, "%s", dummyQueries[2]).Returns().Once()
+	m.Mock(validateRequestData).Expects(dummySession, "Query", &dummyDataTemplate).Returns(nil).Once()
 
 	// act
 	var err = dummySession.GetRequestQueries(
@@ -924,6 +1092,7 @@ func TestSessionGetRequestQuery_QueryValid(t *testing.T) {
 	m.Mock(logEndpointRequest).Expects(dummySession, "Query", dummyName, "%s", dummyQueries[dummyIndex]).Returns().Once()
 	m.Mock(tryUnmarshal).Expects(dummyQueries[dummyIndex], gomocker.Anything()).Returns(nil).SideEffects(
 		gomocker.ParamSideEffect(1, 2, func(value *int) { *value = dummyResult })).Once()
+	m.Mock(validateRequestData).Expects(dummySession, "Query", &dummyDataTemplate).Returns(nil).Once()
 
 	// act
 	var err = dummySession.GetRequestQuery(
@@ -1173,6 +1342,7 @@ func TestSessionGetRequestHeaders_Success(t *testing.T) {
 	m.Mock(logEndpointRequest).Expects(dummySession, "Header", dummyName, "%s", dummyHeaders[0]).Returns().Once()
 	m.Mock(logEndpointRequest).Expects(dummySession, "Header", dummyName, "%s", dummyHeaders[1]).Returns().Once()
 	m.Mock(logEndpointRequest).Expects(dummySession, "Header", dummyName, "%s", dummyHeaders[2]).Returns().Once()
+	m.Mock(validateRequestData).Expects(dummySession, "Header", &dummyDataTemplate).Returns(nil).Once()
 
 	// act
 	var err = dummySession.GetRequestHeaders(
@@ -1344,6 +1514,7 @@ func TestSessionGetRequestHeader_HeaderValid(t *testing.T) {
 	m.Mock(logEndpointRequest).Expects(dummySession, "Header", dummyName, "%s", dummyHeaders[dummyIndex]).Returns().Once()
 	m.Mock(tryUnmarshal).Expects(dummyHeaders[dummyIndex], gomocker.Anything()).Returns(nil).SideEffects(
 		gomocker.ParamSideEffect(1, 2, func(value *int) { *value = dummyResult })).Once()
+	m.Mock(validateRequestData).Expects(dummySession, "Header", &dummyDataTemplate).Returns(nil).Once()
 
 	// act
 	var err = dummySession.GetRequestHeader(
@@ -1363,6 +1534,130 @@ type dummyAttachment struct {
 	Test int
 }
 
+func TestBindRequest_HappyPath(t *testing.T) {
+	// arrange
+	type dummyTemplate struct {
+		Foo int `query:"foo"`
+	}
+	var dummyResult = rand.Int()
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// SUT
+	var sut = &session{}
+
+	// expect
+	m.Mock((*session).BindRequest).Expects(sut, gomocker.Anything()).Returns(dummyError).SideEffects(
+		gomocker.ParamSideEffect(1, 2, func(value *dummyTemplate) { value.Foo = dummyResult })).Once()
+
+	// act
+	var result, err = BindRequest[dummyTemplate](sut)
+
+	// assert
+	assert.Equal(t, dummyResult, result.Foo)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestSessionBindRequest_NilSession(t *testing.T) {
+	// arrange
+	var dummyDataTemplate struct{}
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageSessionNil).Returns(dummyAppError).Once()
+
+	// SUT
+	var dummySession *session
+
+	// act
+	var err = dummySession.BindRequest(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestSessionBindRequest_DataTemplateInvalid(t *testing.T) {
+	// arrange
+	var dummyDataTemplate int
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// SUT
+	var dummySession = &session{}
+
+	// expect
+	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageDataTemplateInvalid).Returns(dummyAppError).Once()
+
+	// act
+	var err = dummySession.BindRequest(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestSessionBindRequest_BindErrors(t *testing.T) {
+	// arrange
+	var dummyDataTemplate struct{}
+	var dummyBindErrors = []error{
+		errors.New("some bind error 1"),
+		errors.New("some bind error 2"),
+	}
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// SUT
+	var dummySession = &session{}
+
+	// expect
+	m.Mock(bindRequestFields).Expects(dummySession, gomocker.Anything()).Returns(dummyBindErrors).Once()
+	m.Mock(newAppError).Expects(errorCodeBadRequest, errorMessageRequestBindingFailed, dummyBindErrors[0], dummyBindErrors[1]).Returns(dummyAppError).Once()
+
+	// act
+	var err = dummySession.BindRequest(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestSessionBindRequest_Success(t *testing.T) {
+	// arrange
+	var dummyDataTemplate struct{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// SUT
+	var dummySession = &session{}
+
+	// expect
+	m.Mock(bindRequestFields).Expects(dummySession, gomocker.Anything()).Returns(nil).Once()
+	m.Mock(validateRequestData).Expects(dummySession, "Binding", &dummyDataTemplate).Returns(dummyError).Once()
+
+	// act
+	var err = dummySession.BindRequest(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
 func TestSessionAttach_NilSessionObject(t *testing.T) {
 	// arrange
 	var dummyName = "some name"
@@ -1715,6 +2010,40 @@ func TestSessionGetAttachment_Success(t *testing.T) {
 	assert.Equal(t, dummyValue, dummyDataTemplate)
 }
 
+func TestSessionAddCleanup(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var called = 0
+
+	// act
+	dummySession.addCleanup(func() { called++ })
+	dummySession.addCleanup(func() { called++ })
+
+	// assert
+	assert.Len(t, dummySession.cleanups, 2)
+	assert.Zero(t, called)
+}
+
+func TestReleaseSession(t *testing.T) {
+	// arrange
+	var order []int
+	var dummySession = &session{
+		cleanups: []func(){
+			func() { order = append(order, 1) },
+			func() { order = append(order, 2) },
+		},
+	}
+
+	// SUT + act
+	releaseSession(
+		dummySession,
+	)
+
+	// assert
+	assert.Equal(t, []int{2, 1}, order)
+	assert.Nil(t, dummySession.cleanups)
+}
+
 func TestSessionGetMethodName_UnknownCaller(t *testing.T) {
 	// arrange
 	var dummyPC = uintptr(rand.Int())
@@ -1894,6 +2223,37 @@ func TestSessionLogMethodExit(t *testing.T) {
 	dummySession.LogMethodExit()
 }
 
+func TestSessionAddLogAttributes_NilSession(t *testing.T) {
+	// arrange
+	var dummySession *session
+
+	// SUT + act
+	dummySession.AddLogAttributes(
+		slog.String("some key", "some value"),
+	)
+}
+
+func TestSessionAddLogAttributes_HappyPath(t *testing.T) {
+	// arrange
+	var dummyAttribute1 = slog.String("some key 1", "some value 1")
+	var dummyAttribute2 = slog.Int("some key 2", rand.Int())
+	var dummyAttribute3 = slog.Bool("some key 3", true)
+
+	// SUT
+	var dummySession = &session{
+		logAttributes: []slog.Attr{dummyAttribute1},
+	}
+
+	// act
+	dummySession.AddLogAttributes(
+		dummyAttribute2,
+		dummyAttribute3,
+	)
+
+	// assert
+	assert.Equal(t, []slog.Attr{dummyAttribute1, dummyAttribute2, dummyAttribute3}, dummySession.logAttributes)
+}
+
 func TestSessionCreateWebcallRequest(t *testing.T) {
 	// arrange
 	var dummySessionID = uuid.New()
@@ -1931,4 +2291,41 @@ func TestSessionCreateWebcallRequest(t *testing.T) {
 	assert.Equal(t, dummySendClientCert, webrequest.sendClientCert)
 	assert.Zero(t, webrequest.retryDelay)
 	assert.Empty(t, webrequest.dataReceivers)
+	assert.Nil(t, webrequest.ctx)
+	assert.Zero(t, webrequest.timeout)
+	assert.Nil(t, webrequest.retryPolicy)
+	assert.Nil(t, webrequest.payloadStream)
+}
+
+func TestSessionCreateStreamingWebcallRequest(t *testing.T) {
+	// arrange
+	var dummySessionID = uuid.New()
+	var dummyMethod = "some method"
+	var dummyURL = "some URL"
+	var dummyPayload = strings.NewReader("some payload")
+	var dummySendClientCert = rand.IntN(100) < 50
+
+	// SUT
+	var dummySession = &session{
+		id: dummySessionID,
+	}
+
+	// act
+	var result = dummySession.CreateStreamingWebcallRequest(
+		dummyMethod,
+		dummyURL,
+		dummyPayload,
+		dummySendClientCert,
+	)
+	var webrequest, ok = result.(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummySession, webrequest.session)
+	assert.Equal(t, dummyMethod, webrequest.method)
+	assert.Equal(t, dummyURL, webrequest.url)
+	assert.Empty(t, webrequest.payload)
+	assert.Equal(t, dummySendClientCert, webrequest.sendClientCert)
+	assert.Empty(t, webrequest.dataReceivers)
+	assert.Equal(t, dummyPayload, webrequest.payloadStream.reader)
 }
@@ -0,0 +1,226 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ShutdownPhase is the named phase of the graceful shutdown of the server
+type ShutdownPhase string
+
+// These are the shutdown phases in order of execution; ForceClose only happens when the connections are not drained before the Drain phase times out
+const (
+	// ShutdownPhasePreDrain happens right after the shutdown signal is received, when readiness starts failing while the server still accepts new requests
+	ShutdownPhasePreDrain ShutdownPhase = "PreDrain"
+	// ShutdownPhaseDrain happens when the server stops accepting new requests and waits for in-flight requests to complete
+	ShutdownPhaseDrain ShutdownPhase = "Drain"
+	// ShutdownPhaseForceClose happens when the Drain phase times out, closing all remaining connections and cancelling all in-flight webcalls
+	ShutdownPhaseForceClose ShutdownPhase = "ForceClose"
+	// ShutdownPhasePostDrain happens after all connections are closed, e.g. for flushing telemetry or closing database connections
+	ShutdownPhasePostDrain ShutdownPhase = "PostDrain"
+)
+
+// defaultShutdownTimeout is the timeout of shutdown phases other than Drain when not customized
+const defaultShutdownTimeout = 30 * time.Second
+
+var (
+	webcallsContext, cancelWebcalls = context.WithCancel(context.Background())
+	webcallsContextLock             sync.RWMutex
+)
+
+func getWebcallsContext() context.Context {
+	webcallsContextLock.RLock()
+	defer webcallsContextLock.RUnlock()
+	return webcallsContext
+}
+
+// forceCancelWebcalls cancels the webcalls in flight and renews the context in the same step, so that webcalls made afterwards, e.g. by later shutdown hooks, are not cancelled
+func forceCancelWebcalls() {
+	webcallsContextLock.Lock()
+	defer webcallsContextLock.Unlock()
+	cancelWebcalls()
+	webcallsContext, cancelWebcalls = context.WithCancel(
+		context.Background(),
+	)
+}
+
+// getShutdownTimeout returns the customized timeout of the shutdown phase, falling back to GraceShutdownWaitTime for the Drain phase and 30 seconds for others
+func getShutdownTimeout(session *session, phase ShutdownPhase) time.Duration {
+	var timeout = session.customization.ShutdownTimeout(
+		phase,
+	)
+	if timeout > 0 {
+		return timeout
+	}
+	if phase == ShutdownPhaseDrain {
+		return session.customization.GraceShutdownWaitTime()
+	}
+	return defaultShutdownTimeout
+}
+
+func beginShutdownPhase(session *session, phase ShutdownPhase) (context.Context, context.CancelFunc) {
+	var timeout = getShutdownTimeout(
+		session,
+		phase,
+	)
+	logAppRoot(
+		session,
+		LogLevelInfo,
+		"shutdown",
+		string(phase),
+		"Shutdown phase started with timeout %v",
+		timeout,
+	)
+	return context.WithTimeout(
+		context.Background(),
+		timeout,
+	)
+}
+
+func endShutdownPhase(session *session, phase ShutdownPhase, cancelCallback context.CancelFunc) {
+	cancelCallback()
+	logAppRoot(
+		session,
+		LogLevelInfo,
+		"shutdown",
+		string(phase),
+		"Shutdown phase finished",
+	)
+}
+
+func runShutdownHook(session *session, phaseContext context.Context, phase ShutdownPhase) {
+	var hookError = session.customization.ShutdownHook(
+		phaseContext,
+		phase,
+	)
+	if hookError != nil {
+		logAppRoot(
+			session,
+			LogLevelWarn,
+			"shutdown",
+			string(phase),
+			"Shutdown hook failed: %+v",
+			hookError,
+		)
+	}
+}
+
+// drainConnections shuts down all servers concurrently, which stop accepting new requests and wait for in-flight requests, while running the Drain hook concurrently
+func drainConnections(session *session, phaseContext context.Context, servers []*hostedServer) error {
+	var hookDone = make(chan bool)
+	go func() {
+		runShutdownHook(
+			session,
+			phaseContext,
+			ShutdownPhaseDrain,
+		)
+		hookDone <- true
+	}()
+	var shutdownErrors = make([]error, len(servers))
+	var shutdownGroup sync.WaitGroup
+	for index, hosted := range servers {
+		shutdownGroup.Go(func() {
+			shutdownErrors[index] = shutdownServer(
+				phaseContext,
+				hosted.server,
+			)
+		})
+	}
+	shutdownGroup.Wait()
+	<-hookDone
+	return errors.Join(
+		shutdownErrors...,
+	)
+}
+
+// forceCloseConnections closes all remaining connections of all servers and cancels all in-flight webcalls
+func forceCloseConnections(session *session, phaseContext context.Context, servers []*hostedServer) {
+	forceCancelWebcalls()
+	runShutdownHook(
+		session,
+		phaseContext,
+		ShutdownPhaseForceClose,
+	)
+	for _, hosted := range servers {
+		var closeError = hosted.server.Close()
+		if closeError != nil {
+			logAppRoot(
+				session,
+				LogLevelWarn,
+				"shutdown",
+				string(ShutdownPhaseForceClose),
+				"Server close failed for listener [%v]: %+v",
+				hosted.setting.Name,
+				closeError,
+			)
+		}
+	}
+}
+
+// shutdownInPhases gracefully shuts down all servers together through the PreDrain, Drain, ForceClose (only if the Drain phase times out) and PostDrain phases, returning the error of draining connections if any
+func shutdownInPhases(session *session, servers []*hostedServer) error {
+	var preDrainContext, preDrainCancel = beginShutdownPhase(
+		session,
+		ShutdownPhasePreDrain,
+	)
+	runShutdownHook(
+		session,
+		preDrainContext,
+		ShutdownPhasePreDrain,
+	)
+	drainServer(
+		session,
+	)
+	endShutdownPhase(
+		session,
+		ShutdownPhasePreDrain,
+		preDrainCancel,
+	)
+	var drainContext, drainCancel = beginShutdownPhase(
+		session,
+		ShutdownPhaseDrain,
+	)
+	var shutdownError = drainConnections(
+		session,
+		drainContext,
+		servers,
+	)
+	endShutdownPhase(
+		session,
+		ShutdownPhaseDrain,
+		drainCancel,
+	)
+	if shutdownError != nil {
+		var forceCloseContext, forceCloseCancel = beginShutdownPhase(
+			session,
+			ShutdownPhaseForceClose,
+		)
+		forceCloseConnections(
+			session,
+			forceCloseContext,
+			servers,
+		)
+		endShutdownPhase(
+			session,
+			ShutdownPhaseForceClose,
+			forceCloseCancel,
+		)
+	}
+	var postDrainContext, postDrainCancel = beginShutdownPhase(
+		session,
+		ShutdownPhasePostDrain,
+	)
+	runShutdownHook(
+		session,
+		postDrainContext,
+		ShutdownPhasePostDrain,
+	)
+	endShutdownPhase(
+		session,
+		ShutdownPhasePostDrain,
+		postDrainCancel,
+	)
+	return shutdownError
+}
@@ -0,0 +1,334 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type dummyShutdownCustomization struct {
+	DefaultCustomization
+	timeouts  map[ShutdownPhase]time.Duration
+	hookError error
+}
+
+func (customization *dummyShutdownCustomization) ShutdownTimeout(phase ShutdownPhase) time.Duration {
+	return customization.timeouts[phase]
+}
+
+func (customization *dummyShutdownCustomization) GraceShutdownWaitTime() time.Duration {
+	return time.Minute
+}
+
+func (customization *dummyShutdownCustomization) ShutdownHook(ctx context.Context, phase ShutdownPhase) error {
+	return customization.hookError
+}
+
+func TestForceCancelWebcalls(t *testing.T) {
+	// arrange
+	var previousContext = getWebcallsContext()
+
+	// SUT + act
+	forceCancelWebcalls()
+
+	// assert
+	assert.Equal(t, context.Canceled, previousContext.Err())
+	assert.NoError(t, getWebcallsContext().Err())
+}
+
+func TestGetShutdownTimeout_Customized(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummyShutdownCustomization{
+			timeouts: map[ShutdownPhase]time.Duration{
+				ShutdownPhaseDrain: time.Second,
+			},
+		},
+	}
+
+	// SUT + act
+	var result = getShutdownTimeout(
+		dummySession,
+		ShutdownPhaseDrain,
+	)
+
+	// assert
+	assert.Equal(t, time.Second, result)
+}
+
+func TestGetShutdownTimeout_DefaultDrain(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummyShutdownCustomization{},
+	}
+
+	// SUT + act
+	var result = getShutdownTimeout(
+		dummySession,
+		ShutdownPhaseDrain,
+	)
+
+	// assert
+	assert.Equal(t, time.Minute, result)
+}
+
+func TestGetShutdownTimeout_DefaultOthers(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummyShutdownCustomization{},
+	}
+
+	// SUT + act
+	var result = getShutdownTimeout(
+		dummySession,
+		ShutdownPhasePostDrain,
+	)
+
+	// assert
+	assert.Equal(t, defaultShutdownTimeout, result)
+}
+
+func TestBeginShutdownPhase(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyTimeout = 5 * time.Second
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getShutdownTimeout).Expects(dummySession, ShutdownPhasePreDrain).Returns(dummyTimeout).Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "shutdown", "PreDrain", "Shutdown phase started with timeout %v", dummyTimeout).Returns().Once()
+
+	// SUT + act
+	var result, cancel = beginShutdownPhase(
+		dummySession,
+		ShutdownPhasePreDrain,
+	)
+	defer cancel()
+
+	// assert
+	var deadline, hasDeadline = result.Deadline()
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(dummyTimeout), deadline, time.Second)
+}
+
+func TestEndShutdownPhase(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyCancel = func() {}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(dummyCancel).Expects().Returns().Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelInfo, "shutdown", "Drain", "Shutdown phase finished").Returns().Once()
+
+	// SUT + act
+	endShutdownPhase(
+		dummySession,
+		ShutdownPhaseDrain,
+		dummyCancel,
+	)
+}
+
+func TestRunShutdownHook_NoError(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		customization: &dummyShutdownCustomization{},
+	}
+
+	// SUT + act
+	runShutdownHook(
+		dummySession,
+		context.Background(),
+		ShutdownPhasePostDrain,
+	)
+}
+
+func TestRunShutdownHook_Error(t *testing.T) {
+	// arrange
+	var dummyHookError = errors.New("some hook error")
+	var dummySession = &session{
+		customization: &dummyShutdownCustomization{
+			hookError: dummyHookError,
+		},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelWarn, "shutdown", "PostDrain", "Shutdown hook failed: %+v", dummyHookError).Returns().Once()
+
+	// SUT + act
+	runShutdownHook(
+		dummySession,
+		context.Background(),
+		ShutdownPhasePostDrain,
+	)
+}
+
+func TestDrainConnections(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyContext = context.TODO()
+	var dummyServer = &http.Server{}
+	var dummyServers = []*hostedServer{{server: dummyServer}}
+	var dummyShutdownError = errors.New("some shutdown error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runShutdownHook).Expects(dummySession, dummyContext, ShutdownPhaseDrain).Returns().Once()
+	m.Mock(shutdownServer).Expects(dummyContext, dummyServer).Returns(dummyShutdownError).Once()
+
+	// SUT + act
+	var err = drainConnections(
+		dummySession,
+		dummyContext,
+		dummyServers,
+	)
+
+	// assert
+	assert.ErrorIs(t, err, dummyShutdownError)
+}
+
+func TestDrainConnections_MultipleServers(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyContext = context.TODO()
+	var dummyServers = []*hostedServer{
+		{server: &http.Server{}},
+		{server: &http.Server{}},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(runShutdownHook).Expects(dummySession, dummyContext, ShutdownPhaseDrain).Returns().Once()
+
+	// SUT + act
+	var err = drainConnections(
+		dummySession,
+		dummyContext,
+		dummyServers,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestForceCloseConnections(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyContext = context.TODO()
+	var dummyServer1 = &http.Server{}
+	var dummyServer2 = &http.Server{}
+	var dummyServers = []*hostedServer{
+		{setting: ListenerSetting{Name: "some name 1"}, server: dummyServer1},
+		{setting: ListenerSetting{Name: "some name 2"}, server: dummyServer2},
+	}
+	var dummyCloseError = errors.New("some close error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(forceCancelWebcalls).Expects().Returns().Once()
+	m.Mock(runShutdownHook).Expects(dummySession, dummyContext, ShutdownPhaseForceClose).Returns().Once()
+	m.Mock((*http.Server).Close).Expects(dummyServer1).Returns(nil).Once()
+	m.Mock((*http.Server).Close).Expects(dummyServer2).Returns(dummyCloseError).Once()
+	m.Mock(logAppRoot).Expects(dummySession, LogLevelWarn, "shutdown", "ForceClose", "Server close failed for listener [%v]: %+v", "some name 2", dummyCloseError).Returns().Once()
+
+	// SUT + act
+	forceCloseConnections(
+		dummySession,
+		dummyContext,
+		dummyServers,
+	)
+}
+
+func TestShutdownInPhases_Drained(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyServers = []*hostedServer{{server: &http.Server{}}}
+	type contextKey struct{}
+	var dummyPreDrainContext = context.WithValue(context.TODO(), contextKey{}, ShutdownPhasePreDrain)
+	var dummyDrainContext = context.WithValue(context.TODO(), contextKey{}, ShutdownPhaseDrain)
+	var dummyPostDrainContext = context.WithValue(context.TODO(), contextKey{}, ShutdownPhasePostDrain)
+	var dummyCancel = func() {}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(beginShutdownPhase).Expects(dummySession, ShutdownPhasePreDrain).Returns(dummyPreDrainContext, dummyCancel).Once()
+	m.Mock(runShutdownHook).Expects(dummySession, dummyPreDrainContext, ShutdownPhasePreDrain).Returns().Once()
+	m.Mock(drainServer).Expects(dummySession).Returns().Once()
+	m.Mock(endShutdownPhase).Expects(dummySession, ShutdownPhasePreDrain, gomocker.Anything()).Returns().Once()
+	m.Mock(beginShutdownPhase).Expects(dummySession, ShutdownPhaseDrain).Returns(dummyDrainContext, dummyCancel).Once()
+	m.Mock(drainConnections).Expects(dummySession, dummyDrainContext, dummyServers).Returns(nil).Once()
+	m.Mock(endShutdownPhase).Expects(dummySession, ShutdownPhaseDrain, gomocker.Anything()).Returns().Once()
+	m.Mock(beginShutdownPhase).Expects(dummySession, ShutdownPhasePostDrain).Returns(dummyPostDrainContext, dummyCancel).Once()
+	m.Mock(runShutdownHook).Expects(dummySession, dummyPostDrainContext, ShutdownPhasePostDrain).Returns().Once()
+	m.Mock(endShutdownPhase).Expects(dummySession, ShutdownPhasePostDrain, gomocker.Anything()).Returns().Once()
+
+	// SUT + act
+	var err = shutdownInPhases(
+		dummySession,
+		dummyServers,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestShutdownInPhases_ForceClosed(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyServers = []*hostedServer{{server: &http.Server{}}}
+	type contextKey struct{}
+	var dummyPreDrainContext = context.WithValue(context.TODO(), contextKey{}, ShutdownPhasePreDrain)
+	var dummyDrainContext = context.WithValue(context.TODO(), contextKey{}, ShutdownPhaseDrain)
+	var dummyForceCloseContext = context.WithValue(context.TODO(), contextKey{}, ShutdownPhaseForceClose)
+	var dummyPostDrainContext = context.WithValue(context.TODO(), contextKey{}, ShutdownPhasePostDrain)
+	var dummyCancel = func() {}
+	var dummyShutdownError = context.DeadlineExceeded
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(beginShutdownPhase).Expects(dummySession, ShutdownPhasePreDrain).Returns(dummyPreDrainContext, dummyCancel).Once()
+	m.Mock(runShutdownHook).Expects(dummySession, dummyPreDrainContext, ShutdownPhasePreDrain).Returns().Once()
+	m.Mock(drainServer).Expects(dummySession).Returns().Once()
+	m.Mock(endShutdownPhase).Expects(dummySession, ShutdownPhasePreDrain, gomocker.Anything()).Returns().Once()
+	m.Mock(beginShutdownPhase).Expects(dummySession, ShutdownPhaseDrain).Returns(dummyDrainContext, dummyCancel).Once()
+	m.Mock(drainConnections).Expects(dummySession, dummyDrainContext, dummyServers).Returns(dummyShutdownError).Once()
+	m.Mock(endShutdownPhase).Expects(dummySession, ShutdownPhaseDrain, gomocker.Anything()).Returns().Once()
+	m.Mock(beginShutdownPhase).Expects(dummySession, ShutdownPhaseForceClose).Returns(dummyForceCloseContext, dummyCancel).Once()
+	m.Mock(forceCloseConnections).Expects(dummySession, dummyForceCloseContext, dummyServers).Returns().Once()
+	m.Mock(endShutdownPhase).Expects(dummySession, ShutdownPhaseForceClose, gomocker.Anything()).Returns().Once()
+	m.Mock(beginShutdownPhase).Expects(dummySession, ShutdownPhasePostDrain).Returns(dummyPostDrainContext, dummyCancel).Once()
+	m.Mock(runShutdownHook).Expects(dummySession, dummyPostDrainContext, ShutdownPhasePostDrain).Returns().Once()
+	m.Mock(endShutdownPhase).Expects(dummySession, ShutdownPhasePostDrain, gomocker.Anything()).Returns().Once()
+
+	// SUT + act
+	var err = shutdownInPhases(
+		dummySession,
+		dummyServers,
+	)
+
+	// assert
+	assert.Equal(t, dummyShutdownError, err)
+}
@@ -0,0 +1,247 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer and meter creating spans and metrics for sessions and webcalls
+const instrumentationName = "github.com/zhongjie-cai/web-server"
+
+// These are the attribute keys set on spans and metrics, following OpenTelemetry semantic conventions where applicable
+const (
+	attributeHTTPMethod     = "http.request.method"
+	attributeHTTPRoute      = "http.route"
+	attributeHTTPStatusCode = "http.response.status_code"
+	attributeServerAddress  = "server.address"
+	attributeURLFull        = "url.full"
+	attributeAppErrorCode   = "app.error.code"
+)
+
+var (
+	telemetryTracer       trace.Tracer
+	serverRequestDuration metric.Float64Histogram = metricnoop.Float64Histogram{}
+	clientRequestDuration metric.Float64Histogram = metricnoop.Float64Histogram{}
+)
+
+// initializeTelemetry sets up the tracer and the duration histograms from the customized providers; spans are skipped if no tracer provider is given, and metrics are dropped if no meter provider is given
+func initializeTelemetry(
+	tracerProvider trace.TracerProvider,
+	meterProvider metric.MeterProvider,
+) {
+	telemetryTracer = nil
+	if !isInterfaceValueNil(tracerProvider) {
+		telemetryTracer = tracerProvider.Tracer(
+			instrumentationName,
+		)
+	}
+	if isInterfaceValueNil(meterProvider) {
+		meterProvider = metricnoop.NewMeterProvider()
+	}
+	var meter = meterProvider.Meter(
+		instrumentationName,
+	)
+	serverRequestDuration, _ = meter.Float64Histogram(
+		"http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP server requests handled by sessions"),
+	)
+	clientRequestDuration, _ = meter.Float64Histogram(
+		"http.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP client requests processed as webcalls"),
+	)
+}
+
+// getRemoteSpanContext converts the incoming trace context of the session into the remote parent of its server span
+func getRemoteSpanContext(incoming traceContext) trace.SpanContext {
+	var traceID, _ = trace.TraceIDFromHex(incoming.traceID)
+	var spanID, _ = trace.SpanIDFromHex(incoming.parentSpanID)
+	var traceFlags, _ = strconv.ParseUint(incoming.traceFlags, 16, 8)
+	var traceState, _ = trace.ParseTraceState(incoming.traceState)
+	return trace.NewSpanContext(
+		trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.TraceFlags(traceFlags),
+			TraceState: traceState,
+			Remote:     true,
+		},
+	)
+}
+
+func getServerSpanName(method string, pattern string) string {
+	if pattern == "" {
+		return method
+	}
+	return method + " " + pattern
+}
+
+// startServerSpan opens the server span of the session named after its route pattern, continuing the incoming trace context, and carries the span in the context of the session's HTTP request
+func startServerSpan(session *session) {
+	if telemetryTracer == nil {
+		return
+	}
+	var requestContext = session.request.Context()
+	if session.trace.parentSpanID != "" &&
+		!trace.SpanContextFromContext(requestContext).IsValid() {
+		requestContext = trace.ContextWithRemoteSpanContext(
+			requestContext,
+			getRemoteSpanContext(
+				session.trace,
+			),
+		)
+	}
+	var spanContext, span = telemetryTracer.Start(
+		requestContext,
+		getServerSpanName(
+			session.request.Method,
+			session.pattern,
+		),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String(attributeHTTPMethod, session.request.Method),
+			attribute.String(attributeHTTPRoute, session.pattern),
+		),
+	)
+	session.span = span
+	session.request = session.request.WithContext(
+		spanContext,
+	)
+	if span.SpanContext().IsValid() {
+		session.trace.traceID = span.SpanContext().TraceID().String()
+		session.trace.spanID = span.SpanContext().SpanID().String()
+	}
+}
+
+// recordSpanError records the error on the span, together with its error code if it is an AppError
+func recordSpanError(span trace.Span, err error) {
+	if span == nil || err == nil {
+		return
+	}
+	span.RecordError(err)
+	var appError AppError
+	if errors.As(err, &appError) {
+		span.SetAttributes(
+			attribute.String(attributeAppErrorCode, appError.ErrorCode()),
+		)
+	}
+}
+
+// finishServerTelemetry closes the server span of the session with its response status code, and records the duration of the session
+func finishServerTelemetry(session *session, duration time.Duration) {
+	var attributes = []attribute.KeyValue{
+		attribute.String(attributeHTTPMethod, session.request.Method),
+		attribute.String(attributeHTTPRoute, session.pattern),
+	}
+	if session.statusCode != 0 {
+		attributes = append(
+			attributes,
+			attribute.Int(attributeHTTPStatusCode, session.statusCode),
+		)
+	}
+	serverRequestDuration.Record(
+		session.request.Context(),
+		duration.Seconds(),
+		metric.WithAttributes(attributes...),
+	)
+	if session.span == nil {
+		return
+	}
+	session.span.SetAttributes(attributes...)
+	if session.statusCode >= http.StatusInternalServerError {
+		session.span.SetStatus(
+			codes.Error,
+			http.StatusText(session.statusCode),
+		)
+	}
+	session.span.End()
+}
+
+func getServerAddress(rawURL string) string {
+	var parsedURL, parseError = url.Parse(rawURL)
+	if parseError != nil {
+		return ""
+	}
+	return parsedURL.Hostname()
+}
+
+// startClientSpan opens the client span of the webcall as a child of the span in the webcall context, or of the session's server span if the webcall context carries none
+func startClientSpan(webRequest *webRequest, requestContext context.Context) (context.Context, trace.Span) {
+	if telemetryTracer == nil {
+		return requestContext, nil
+	}
+	if !trace.SpanContextFromContext(requestContext).IsValid() &&
+		webRequest.session.span != nil {
+		requestContext = trace.ContextWithSpan(
+			requestContext,
+			webRequest.session.span,
+		)
+	}
+	return telemetryTracer.Start(
+		requestContext,
+		webRequest.method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String(attributeHTTPMethod, webRequest.method),
+			attribute.String(attributeServerAddress, getServerAddress(webRequest.url)),
+			attribute.String(attributeURLFull, webRequest.url),
+		),
+	)
+}
+
+// finishClientTelemetry closes the client span of the webcall with its error and, if any response is received, its status code, and records the duration of the webcall
+func finishClientTelemetry(
+	webRequest *webRequest,
+	requestContext context.Context,
+	span trace.Span,
+	duration time.Duration,
+	statusCode int,
+	responseError error,
+) {
+	var attributes = []attribute.KeyValue{
+		attribute.String(attributeHTTPMethod, webRequest.method),
+		attribute.String(attributeServerAddress, getServerAddress(webRequest.url)),
+	}
+	if webRequest.responseReceived {
+		attributes = append(
+			attributes,
+			attribute.Int(attributeHTTPStatusCode, statusCode),
+		)
+	}
+	clientRequestDuration.Record(
+		requestContext,
+		duration.Seconds(),
+		metric.WithAttributes(attributes...),
+	)
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attributes...)
+	recordSpanError(
+		span,
+		responseError,
+	)
+	if responseError != nil {
+		span.SetStatus(
+			codes.Error,
+			responseError.Error(),
+		)
+	} else if statusCode >= http.StatusBadRequest {
+		span.SetStatus(
+			codes.Error,
+			http.StatusText(statusCode),
+		)
+	}
+	span.End()
+}
@@ -0,0 +1,507 @@
+package webserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setupTestTelemetry(t *testing.T) (*tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	var exporter = tracetest.NewInMemoryExporter()
+	var reader = sdkmetric.NewManualReader()
+	initializeTelemetry(
+		sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+	t.Cleanup(func() {
+		initializeTelemetry(nil, nil)
+	})
+	return exporter, reader
+}
+
+func collectHistogramPoints(t *testing.T, reader *sdkmetric.ManualReader, name string) []metricdata.HistogramDataPoint[float64] {
+	var resourceMetrics metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &resourceMetrics))
+	for _, scopeMetrics := range resourceMetrics.ScopeMetrics {
+		for _, metrics := range scopeMetrics.Metrics {
+			if metrics.Name == name {
+				return metrics.Data.(metricdata.Histogram[float64]).DataPoints
+			}
+		}
+	}
+	return nil
+}
+
+func TestInitializeTelemetry_NoProviders(t *testing.T) {
+	// SUT + act
+	initializeTelemetry(
+		nil,
+		nil,
+	)
+
+	// assert
+	assert.Nil(t, telemetryTracer)
+	assert.NotNil(t, serverRequestDuration)
+	assert.NotNil(t, clientRequestDuration)
+}
+
+func TestInitializeTelemetry_WithProviders(t *testing.T) {
+	// SUT + act
+	setupTestTelemetry(t)
+
+	// assert
+	assert.NotNil(t, telemetryTracer)
+	assert.NotNil(t, serverRequestDuration)
+	assert.NotNil(t, clientRequestDuration)
+}
+
+func TestGetRemoteSpanContext(t *testing.T) {
+	// arrange
+	var dummyTrace = traceContext{
+		traceID:      "0af7651916cd43dd8448eb211c80319c",
+		parentSpanID: "b7ad6b7169203331",
+		traceFlags:   "01",
+		traceState:   "foo=bar",
+	}
+
+	// SUT + act
+	var result = getRemoteSpanContext(
+		dummyTrace,
+	)
+
+	// assert
+	assert.True(t, result.IsValid())
+	assert.True(t, result.IsRemote())
+	assert.True(t, result.IsSampled())
+	assert.Equal(t, dummyTrace.traceID, result.TraceID().String())
+	assert.Equal(t, dummyTrace.parentSpanID, result.SpanID().String())
+	assert.Equal(t, dummyTrace.traceState, result.TraceState().String())
+}
+
+func TestGetServerSpanName(t *testing.T) {
+	// assert
+	assert.Equal(t, "GET", getServerSpanName("GET", ""))
+	assert.Equal(t, "GET /items/{id}", getServerSpanName("GET", "/items/{id}"))
+}
+
+func TestStartServerSpan_NoTracer(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost/items", nil)
+	var dummySession = &session{
+		request: dummyHTTPRequest,
+	}
+
+	// SUT + act
+	startServerSpan(
+		dummySession,
+	)
+
+	// assert
+	assert.Nil(t, dummySession.span)
+	assert.Equal(t, dummyHTTPRequest, dummySession.request)
+}
+
+func TestStartServerSpan_NewTrace(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost/items/1", nil)
+	var dummySession = &session{
+		pattern: "/items/{id}",
+		request: dummyHTTPRequest,
+		trace: traceContext{
+			traceID: "some trace ID",
+			spanID:  "some span ID",
+		},
+	}
+
+	// SUT + act
+	startServerSpan(
+		dummySession,
+	)
+	dummySession.span.End()
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "GET /items/{id}", spans[0].Name)
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind)
+	assert.False(t, spans[0].Parent.IsValid())
+	assert.Contains(t, spans[0].Attributes, attribute.String(attributeHTTPMethod, http.MethodGet))
+	assert.Contains(t, spans[0].Attributes, attribute.String(attributeHTTPRoute, "/items/{id}"))
+	assert.Equal(t, spans[0].SpanContext.TraceID().String(), dummySession.trace.traceID)
+	assert.Equal(t, spans[0].SpanContext.SpanID().String(), dummySession.trace.spanID)
+	assert.Equal(t, dummySession.span, trace.SpanFromContext(dummySession.request.Context()))
+}
+
+func TestStartServerSpan_RemoteParent(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodPost, "http://localhost/items", nil)
+	var dummySession = &session{
+		pattern: "/items",
+		request: dummyHTTPRequest,
+		trace: traceContext{
+			traceID:      "0af7651916cd43dd8448eb211c80319c",
+			spanID:       "some span ID",
+			parentSpanID: "b7ad6b7169203331",
+			traceFlags:   "01",
+		},
+	}
+
+	// SUT + act
+	startServerSpan(
+		dummySession,
+	)
+	dummySession.span.End()
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "POST /items", spans[0].Name)
+	assert.True(t, spans[0].Parent.IsRemote())
+	assert.Equal(t, "b7ad6b7169203331", spans[0].Parent.SpanID().String())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", dummySession.trace.traceID)
+	assert.Equal(t, spans[0].SpanContext.SpanID().String(), dummySession.trace.spanID)
+}
+
+func TestRecordSpanError_NilSpan(t *testing.T) {
+	// SUT + act
+	recordSpanError(
+		nil,
+		errors.New("some error"),
+	)
+}
+
+func TestRecordSpanError_NilError(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var _, dummySpan = telemetryTracer.Start(context.Background(), "some span")
+
+	// SUT + act
+	recordSpanError(
+		dummySpan,
+		nil,
+	)
+	dummySpan.End()
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Events)
+	assert.Empty(t, spans[0].Attributes)
+}
+
+func TestRecordSpanError_NonAppError(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var _, dummySpan = telemetryTracer.Start(context.Background(), "some span")
+
+	// SUT + act
+	recordSpanError(
+		dummySpan,
+		errors.New("some error"),
+	)
+	dummySpan.End()
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Len(t, spans[0].Events, 1)
+	assert.Empty(t, spans[0].Attributes)
+}
+
+func TestRecordSpanError_AppError(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var _, dummySpan = telemetryTracer.Start(context.Background(), "some span")
+	var dummyError = GetNotFound("some error message")
+
+	// SUT + act
+	recordSpanError(
+		dummySpan,
+		dummyError,
+	)
+	dummySpan.End()
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Len(t, spans[0].Events, 1)
+	assert.Contains(t, spans[0].Attributes, attribute.String(attributeAppErrorCode, "NotFound"))
+}
+
+func TestFinishServerTelemetry_NoSpan(t *testing.T) {
+	// arrange
+	var _, reader = setupTestTelemetry(t)
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost/items", nil)
+	var dummySession = &session{
+		pattern: "/items",
+		request: dummyHTTPRequest,
+	}
+
+	// SUT + act
+	finishServerTelemetry(
+		dummySession,
+		time.Second,
+	)
+
+	// assert
+	var points = collectHistogramPoints(t, reader, "http.server.request.duration")
+	assert.Len(t, points, 1)
+	assert.Equal(t, uint64(1), points[0].Count)
+	assert.Equal(t, 1.0, points[0].Sum)
+	assert.Equal(t, attribute.NewSet(
+		attribute.String(attributeHTTPMethod, http.MethodGet),
+		attribute.String(attributeHTTPRoute, "/items"),
+	), points[0].Attributes)
+}
+
+func TestFinishServerTelemetry_Success(t *testing.T) {
+	// arrange
+	var exporter, reader = setupTestTelemetry(t)
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost/items", nil)
+	var dummySession = &session{
+		pattern:    "/items",
+		request:    dummyHTTPRequest,
+		statusCode: http.StatusOK,
+	}
+	startServerSpan(dummySession)
+
+	// SUT + act
+	finishServerTelemetry(
+		dummySession,
+		time.Second,
+	)
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, attribute.Int(attributeHTTPStatusCode, http.StatusOK))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	var points = collectHistogramPoints(t, reader, "http.server.request.duration")
+	assert.Len(t, points, 1)
+	var statusCode, _ = points[0].Attributes.Value(attributeHTTPStatusCode)
+	assert.Equal(t, int64(http.StatusOK), statusCode.AsInt64())
+}
+
+func TestFinishServerTelemetry_ServerError(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var dummyHTTPRequest, _ = http.NewRequest(http.MethodGet, "http://localhost/items", nil)
+	var dummySession = &session{
+		pattern:    "/items",
+		request:    dummyHTTPRequest,
+		statusCode: http.StatusInternalServerError,
+	}
+	startServerSpan(dummySession)
+
+	// SUT + act
+	finishServerTelemetry(
+		dummySession,
+		time.Second,
+	)
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, attribute.Int(attributeHTTPStatusCode, http.StatusInternalServerError))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "Internal Server Error", spans[0].Status.Description)
+}
+
+func TestGetServerAddress(t *testing.T) {
+	// assert
+	assert.Empty(t, getServerAddress("://invalid"))
+	assert.Equal(t, "localhost", getServerAddress("https://localhost:8443/items"))
+}
+
+func TestStartClientSpan_NoTracer(t *testing.T) {
+	// arrange
+	var dummyContext = context.Background()
+	var dummyWebRequest = &webRequest{
+		session: &session{},
+	}
+
+	// SUT + act
+	var resultContext, resultSpan = startClientSpan(
+		dummyWebRequest,
+		dummyContext,
+	)
+
+	// assert
+	assert.Equal(t, dummyContext, resultContext)
+	assert.Nil(t, resultSpan)
+}
+
+func TestStartClientSpan_SessionSpan(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var _, dummySessionSpan = telemetryTracer.Start(context.Background(), "some session span")
+	var dummyWebRequest = &webRequest{
+		session: &session{span: dummySessionSpan},
+		method:  http.MethodPut,
+		url:     "https://localhost:8443/items",
+	}
+
+	// SUT + act
+	var resultContext, resultSpan = startClientSpan(
+		dummyWebRequest,
+		context.Background(),
+	)
+	resultSpan.End()
+
+	// assert
+	assert.Equal(t, resultSpan, trace.SpanFromContext(resultContext))
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, http.MethodPut, spans[0].Name)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Equal(t, dummySessionSpan.SpanContext().SpanID(), spans[0].Parent.SpanID())
+	assert.Contains(t, spans[0].Attributes, attribute.String(attributeServerAddress, "localhost"))
+	assert.Contains(t, spans[0].Attributes, attribute.String(attributeURLFull, "https://localhost:8443/items"))
+}
+
+func TestStartClientSpan_ContextSpan(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var _, dummySessionSpan = telemetryTracer.Start(context.Background(), "some session span")
+	var dummyContext, dummyContextSpan = telemetryTracer.Start(context.Background(), "some context span")
+	var dummyWebRequest = &webRequest{
+		session: &session{span: dummySessionSpan},
+		method:  http.MethodGet,
+		url:     "https://localhost/items",
+	}
+
+	// SUT + act
+	var _, resultSpan = startClientSpan(
+		dummyWebRequest,
+		dummyContext,
+	)
+	resultSpan.End()
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, dummyContextSpan.SpanContext().SpanID(), spans[0].Parent.SpanID())
+}
+
+func TestFinishClientTelemetry_NoSpan(t *testing.T) {
+	// arrange
+	var _, reader = setupTestTelemetry(t)
+	var dummyWebRequest = &webRequest{
+		method: http.MethodGet,
+		url:    "https://localhost/items",
+	}
+
+	// SUT + act
+	finishClientTelemetry(
+		dummyWebRequest,
+		context.Background(),
+		nil,
+		time.Second,
+		0,
+		nil,
+	)
+
+	// assert
+	var points = collectHistogramPoints(t, reader, "http.client.request.duration")
+	assert.Len(t, points, 1)
+	assert.Equal(t, attribute.NewSet(
+		attribute.String(attributeHTTPMethod, http.MethodGet),
+		attribute.String(attributeServerAddress, "localhost"),
+	), points[0].Attributes)
+}
+
+func TestFinishClientTelemetry_Error(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var dummyContext, dummySpan = telemetryTracer.Start(context.Background(), "some span")
+	var dummyWebRequest = &webRequest{
+		method: http.MethodGet,
+		url:    "https://localhost/items",
+	}
+	var dummyError = GetRequestTimeout("some error message")
+
+	// SUT + act
+	finishClientTelemetry(
+		dummyWebRequest,
+		dummyContext,
+		dummySpan,
+		time.Second,
+		http.StatusInternalServerError,
+		dummyError,
+	)
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.NotContains(t, spans[0].Attributes, attribute.Int(attributeHTTPStatusCode, http.StatusInternalServerError))
+	assert.Contains(t, spans[0].Attributes, attribute.String(attributeAppErrorCode, "RequestTimeout"))
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, dummyError.Error(), spans[0].Status.Description)
+}
+
+func TestFinishClientTelemetry_ErrorStatus(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var dummyContext, dummySpan = telemetryTracer.Start(context.Background(), "some span")
+	var dummyWebRequest = &webRequest{
+		method:           http.MethodGet,
+		url:              "https://localhost/items",
+		responseReceived: true,
+	}
+
+	// SUT + act
+	finishClientTelemetry(
+		dummyWebRequest,
+		dummyContext,
+		dummySpan,
+		time.Second,
+		http.StatusNotFound,
+		nil,
+	)
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+	assert.Equal(t, "Not Found", spans[0].Status.Description)
+}
+
+func TestFinishClientTelemetry_Success(t *testing.T) {
+	// arrange
+	var exporter, _ = setupTestTelemetry(t)
+	var dummyContext, dummySpan = telemetryTracer.Start(context.Background(), "some span")
+	var dummyWebRequest = &webRequest{
+		method:           http.MethodGet,
+		url:              "https://localhost/items",
+		responseReceived: true,
+	}
+
+	// SUT + act
+	finishClientTelemetry(
+		dummyWebRequest,
+		dummyContext,
+		dummySpan,
+		time.Second,
+		http.StatusOK,
+		nil,
+	)
+
+	// assert
+	var spans = exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, attribute.Int(attributeHTTPStatusCode, http.StatusOK))
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+}
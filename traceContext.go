@@ -0,0 +1,151 @@
+package webserver
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// These are the header names defined by W3C Trace Context
+const (
+	headerTraceParent = "traceparent"
+	headerTraceState  = "tracestate"
+)
+
+// These are the values used when a new trace is started instead of continuing an incoming one
+const (
+	traceVersion      = "00"
+	traceFlagsSampled = "01"
+)
+
+// traceContext holds the W3C Trace Context of a session, either continued from the incoming request or newly started
+type traceContext struct {
+	traceID      string
+	spanID       string
+	parentSpanID string
+	traceFlags   string
+	traceState   string
+}
+
+func isLowerHex(value string, length int) bool {
+	if len(value) != length {
+		return false
+	}
+	for _, character := range value {
+		if (character < '0' || character > '9') &&
+			(character < 'a' || character > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllZeros(value string) bool {
+	return strings.Trim(value, "0") == ""
+}
+
+// newTraceID generates a random non-zero trace ID of 16 bytes in lower hex
+func newTraceID() string {
+	var high, low = rand.Uint64(), rand.Uint64()
+	for high == 0 && low == 0 {
+		low = rand.Uint64()
+	}
+	return fmt.Sprintf("%016x%016x", high, low)
+}
+
+// newSpanID generates a random non-zero span ID of 8 bytes in lower hex
+func newSpanID() string {
+	var value = rand.Uint64()
+	for value == 0 {
+		value = rand.Uint64()
+	}
+	return fmt.Sprintf("%016x", value)
+}
+
+// parseTraceParent parses the given traceparent header value into trace ID, parent span ID and trace flags; returns false if the value is invalid
+func parseTraceParent(value string) (string, string, string, bool) {
+	var parts = strings.Split(
+		strings.TrimSpace(value),
+		"-",
+	)
+	if len(parts) < 4 ||
+		!isLowerHex(parts[0], 2) ||
+		parts[0] == "ff" ||
+		(parts[0] == traceVersion && len(parts) != 4) ||
+		!isLowerHex(parts[1], 32) ||
+		isAllZeros(parts[1]) ||
+		!isLowerHex(parts[2], 16) ||
+		isAllZeros(parts[2]) ||
+		!isLowerHex(parts[3], 2) {
+		return "", "", "", false
+	}
+	return parts[1], parts[2], parts[3], true
+}
+
+// extractTraceContext continues the trace given by the traceparent and tracestate headers of the request with a new span, or starts a new trace if absent or invalid
+func extractTraceContext(httpRequest *http.Request) traceContext {
+	var traceID, parentSpanID, traceFlags, isValid = parseTraceParent(
+		httpRequest.Header.Get(headerTraceParent),
+	)
+	if !isValid {
+		return traceContext{
+			traceID:    newTraceID(),
+			spanID:     newSpanID(),
+			traceFlags: traceFlagsSampled,
+		}
+	}
+	return traceContext{
+		traceID:      traceID,
+		spanID:       newSpanID(),
+		parentSpanID: parentSpanID,
+		traceFlags:   traceFlags,
+		traceState: strings.Join(
+			httpRequest.Header.Values(headerTraceState),
+			",",
+		),
+	}
+}
+
+func setTraceHeaders(httpRequest *http.Request, traceID string, spanID string, traceFlags string, traceState string) {
+	httpRequest.Header.Set(
+		headerTraceParent,
+		traceVersion+"-"+traceID+"-"+spanID+"-"+traceFlags,
+	)
+	if traceState != "" {
+		httpRequest.Header.Set(
+			headerTraceState,
+			traceState,
+		)
+	}
+}
+
+// injectTraceContext sets the traceparent and tracestate headers of the webcall request with the client span of the webcall if traced, or a new child span of the session's span otherwise, unless the traceparent header is already given by the consumer
+func injectTraceContext(session *session, httpRequest *http.Request) {
+	if session.trace.traceID == "" ||
+		httpRequest.Header.Get(headerTraceParent) != "" {
+		return
+	}
+	var spanContext = trace.SpanContextFromContext(
+		httpRequest.Context(),
+	)
+	if spanContext.IsValid() {
+		setTraceHeaders(
+			httpRequest,
+			spanContext.TraceID().String(),
+			spanContext.SpanID().String(),
+			spanContext.TraceFlags().String(),
+			spanContext.TraceState().String(),
+		)
+		return
+	}
+	setTraceHeaders(
+		httpRequest,
+		session.trace.traceID,
+		newSpanID(),
+		session.trace.traceFlags,
+		session.trace.traceState,
+	)
+}
@@ -0,0 +1,331 @@
+package webserver
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestIsLowerHex_WrongLength(t *testing.T) {
+	// SUT + act
+	var result = isLowerHex("abc", 2)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLowerHex_InvalidCharacter(t *testing.T) {
+	// SUT + act
+	var result = isLowerHex("aB", 2)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsLowerHex_Valid(t *testing.T) {
+	// SUT + act
+	var result = isLowerHex("0123456789abcdef", 16)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestIsAllZeros(t *testing.T) {
+	// assert
+	assert.True(t, isAllZeros("0000"))
+	assert.False(t, isAllZeros("0010"))
+}
+
+func TestNewTraceID_NonZero(t *testing.T) {
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(rand.Uint64).Expects().Returns(uint64(0)).Times(3)
+	m.Mock(rand.Uint64).Expects().Returns(uint64(0xabc)).Once()
+
+	// SUT + act
+	var result = newTraceID()
+
+	// assert
+	assert.Equal(t, "00000000000000000000000000000abc", result)
+}
+
+func TestNewSpanID_NonZero(t *testing.T) {
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(rand.Uint64).Expects().Returns(uint64(0)).Once()
+	m.Mock(rand.Uint64).Expects().Returns(uint64(0xabc)).Once()
+
+	// SUT + act
+	var result = newSpanID()
+
+	// assert
+	assert.Equal(t, "0000000000000abc", result)
+}
+
+func TestParseTraceParent_Invalid(t *testing.T) {
+	// arrange
+	var dummyValues = []string{
+		"",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"0-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01-extra",
+		"00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b716920333-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-1",
+	}
+
+	for _, dummyValue := range dummyValues {
+		// SUT + act
+		var traceID, parentSpanID, traceFlags, isValid = parseTraceParent(
+			dummyValue,
+		)
+
+		// assert
+		assert.Zero(t, traceID, dummyValue)
+		assert.Zero(t, parentSpanID, dummyValue)
+		assert.Zero(t, traceFlags, dummyValue)
+		assert.False(t, isValid, dummyValue)
+	}
+}
+
+func TestParseTraceParent_Valid(t *testing.T) {
+	// SUT + act
+	var traceID, parentSpanID, traceFlags, isValid = parseTraceParent(
+		" 00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01 ",
+	)
+
+	// assert
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", traceID)
+	assert.Equal(t, "b7ad6b7169203331", parentSpanID)
+	assert.Equal(t, "01", traceFlags)
+	assert.True(t, isValid)
+}
+
+func TestParseTraceParent_FutureVersion(t *testing.T) {
+	// SUT + act
+	var traceID, parentSpanID, traceFlags, isValid = parseTraceParent(
+		"01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00-extra",
+	)
+
+	// assert
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", traceID)
+	assert.Equal(t, "b7ad6b7169203331", parentSpanID)
+	assert.Equal(t, "00", traceFlags)
+	assert.True(t, isValid)
+}
+
+func TestExtractTraceContext_NewTrace(t *testing.T) {
+	// arrange
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	var dummyTraceID = "some trace ID"
+	var dummySpanID = "some span ID"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(parseTraceParent).Expects("").Returns("", "", "", false).Once()
+	m.Mock(newTraceID).Expects().Returns(dummyTraceID).Once()
+	m.Mock(newSpanID).Expects().Returns(dummySpanID).Once()
+
+	// SUT + act
+	var result = extractTraceContext(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, traceContext{
+		traceID:    dummyTraceID,
+		spanID:     dummySpanID,
+		traceFlags: traceFlagsSampled,
+	}, result)
+}
+
+func TestExtractTraceContext_ContinueTrace(t *testing.T) {
+	// arrange
+	var dummyTraceParent = "some trace parent"
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{
+			"Traceparent": {dummyTraceParent},
+			"Tracestate":  {"foo=bar", "test=123"},
+		},
+	}
+	var dummyTraceID = "some trace ID"
+	var dummyParentSpanID = "some parent span ID"
+	var dummyTraceFlags = "some trace flags"
+	var dummySpanID = "some span ID"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(parseTraceParent).Expects(dummyTraceParent).Returns(dummyTraceID, dummyParentSpanID, dummyTraceFlags, true).Once()
+	m.Mock(newSpanID).Expects().Returns(dummySpanID).Once()
+
+	// SUT + act
+	var result = extractTraceContext(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, traceContext{
+		traceID:      dummyTraceID,
+		spanID:       dummySpanID,
+		parentSpanID: dummyParentSpanID,
+		traceFlags:   dummyTraceFlags,
+		traceState:   "foo=bar,test=123",
+	}, result)
+}
+
+func TestInjectTraceContext_NoTrace(t *testing.T) {
+	// arrange
+	var dummySession = &session{}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+
+	// SUT + act
+	injectTraceContext(
+		dummySession,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Empty(t, dummyHTTPRequest.Header)
+}
+
+func TestInjectTraceContext_TraceParentGiven(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		trace: traceContext{
+			traceID:    "some trace ID",
+			traceState: "some trace state",
+		},
+	}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{
+			"Traceparent": {"some trace parent"},
+		},
+	}
+
+	// SUT + act
+	injectTraceContext(
+		dummySession,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, "some trace parent", dummyHTTPRequest.Header.Get(headerTraceParent))
+	assert.Empty(t, dummyHTTPRequest.Header.Get(headerTraceState))
+}
+
+func TestInjectTraceContext_NoTraceState(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		trace: traceContext{
+			traceID:    "some trace ID",
+			spanID:     "some span ID",
+			traceFlags: "01",
+		},
+	}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	var dummyChildSpanID = "some child span ID"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newSpanID).Expects().Returns(dummyChildSpanID).Once()
+
+	// SUT + act
+	injectTraceContext(
+		dummySession,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, "00-some trace ID-some child span ID-01", dummyHTTPRequest.Header.Get(headerTraceParent))
+	assert.Empty(t, dummyHTTPRequest.Header.Values(headerTraceState))
+}
+
+func TestInjectTraceContext_WithTraceState(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		trace: traceContext{
+			traceID:    "some trace ID",
+			spanID:     "some span ID",
+			traceFlags: "00",
+			traceState: "foo=bar,test=123",
+		},
+	}
+	var dummyHTTPRequest = &http.Request{
+		Header: http.Header{},
+	}
+	var dummyChildSpanID = "some child span ID"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newSpanID).Expects().Returns(dummyChildSpanID).Once()
+
+	// SUT + act
+	injectTraceContext(
+		dummySession,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, "00-some trace ID-some child span ID-00", dummyHTTPRequest.Header.Get(headerTraceParent))
+	assert.Equal(t, "foo=bar,test=123", dummyHTTPRequest.Header.Get(headerTraceState))
+}
+
+func TestInjectTraceContext_SpanContext(t *testing.T) {
+	// arrange
+	var dummySession = &session{
+		trace: traceContext{
+			traceID:    "some trace ID",
+			spanID:     "some span ID",
+			traceFlags: "01",
+			traceState: "foo=bar",
+		},
+	}
+	var dummyTraceState, _ = trace.ParseTraceState("test=123")
+	var dummySpanContext = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x0a, 0xf7},
+		SpanID:     trace.SpanID{0xb7, 0xad},
+		TraceState: dummyTraceState,
+	})
+	var dummyHTTPRequest, _ = http.NewRequestWithContext(
+		trace.ContextWithSpanContext(context.Background(), dummySpanContext),
+		http.MethodGet,
+		"http://localhost",
+		nil,
+	)
+
+	// SUT + act
+	injectTraceContext(
+		dummySession,
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Equal(t, "00-0af70000000000000000000000000000-b7ad000000000000-00", dummyHTTPRequest.Header.Get(headerTraceParent))
+	assert.Equal(t, "test=123", dummyHTTPRequest.Header.Get(headerTraceState))
+}
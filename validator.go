@@ -0,0 +1,272 @@
+package webserver
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateStruct validates the given data template against the "validate" tags of its struct fields, walking through nested structs, pointers, slices and maps, and returns all violations found
+//
+// Supported rules, separated by commas, are:
+//   - required: the field must not be zero value
+//   - omitempty: the remaining rules are skipped if the field is zero value
+//   - min=n, max=n, len=n: the value of numbers, or the length of strings, slices and maps, must be at least, at most or exactly n
+//   - oneof=a b c: the value must be one of the space separated options
+//
+// Any other rules, e.g. those of 3rd party validators, as well as rules not applicable to the field type, are ignored
+func ValidateStruct(dataTemplate any) []error {
+	return validateValue(
+		"",
+		reflect.ValueOf(dataTemplate),
+	)
+}
+
+func getValidationFieldPath(path string, field reflect.StructField) string {
+	if field.Anonymous {
+		return path
+	}
+	var name, _, _ = strings.Cut(
+		field.Tag.Get("json"),
+		",",
+	)
+	if name == "" || name == "-" {
+		name = field.Name
+	}
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func validateValue(path string, value reflect.Value) []error {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return validateValue(
+			path,
+			value.Elem(),
+		)
+	case reflect.Struct:
+		return validateStructFields(
+			path,
+			value,
+		)
+	case reflect.Slice, reflect.Array:
+		var violations []error
+		for index := 0; index < value.Len(); index++ {
+			violations = append(
+				violations,
+				validateValue(
+					fmt.Sprintf("%v[%v]", path, index),
+					value.Index(index),
+				)...,
+			)
+		}
+		return violations
+	case reflect.Map:
+		var violations []error
+		var iterator = value.MapRange()
+		for iterator.Next() {
+			violations = append(
+				violations,
+				validateValue(
+					fmt.Sprintf("%v[%v]", path, iterator.Key()),
+					iterator.Value(),
+				)...,
+			)
+		}
+		return violations
+	}
+	return nil
+}
+
+func validateStructFields(path string, value reflect.Value) []error {
+	var violations []error
+	for index := 0; index < value.NumField(); index++ {
+		var field = value.Type().Field(index)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+		var fieldPath = getValidationFieldPath(
+			path,
+			field,
+		)
+		var fieldViolations = validateField(
+			fieldPath,
+			value.Field(index),
+			field.Tag.Get("validate"),
+		)
+		if len(fieldViolations) == 0 {
+			fieldViolations = validateValue(
+				fieldPath,
+				value.Field(index),
+			)
+		}
+		violations = append(
+			violations,
+			fieldViolations...,
+		)
+	}
+	return violations
+}
+
+func validateField(path string, value reflect.Value, tag string) []error {
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	var violations []error
+	for _, rule := range strings.Split(tag, ",") {
+		var name, parameter, _ = strings.Cut(
+			strings.TrimSpace(rule),
+			"=",
+		)
+		switch name {
+		case "":
+			continue
+		case "omitempty":
+			if value.IsZero() {
+				return nil
+			}
+			continue
+		case "required":
+			if value.IsZero() {
+				return []error{
+					fmt.Errorf(
+						"field [%v] is required",
+						path,
+					),
+				}
+			}
+			continue
+		}
+		var target = reflect.Indirect(value)
+		if !target.IsValid() {
+			continue
+		}
+		var violation = checkValidationRule(
+			name,
+			parameter,
+			target,
+		)
+		if violation != "" {
+			violations = append(
+				violations,
+				fmt.Errorf(
+					"field [%v] %v",
+					path,
+					violation,
+				),
+			)
+		}
+	}
+	return violations
+}
+
+func getValidationMeasure(value reflect.Value) (float64, bool, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), true, true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), true, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), false, true
+	}
+	return 0, false, false
+}
+
+// checkValidationRule returns the description of the violation of given rule against the value; returns empty string if the value satisfies the rule or the rule is not supported
+func checkValidationRule(name string, parameter string, value reflect.Value) string {
+	if name == "oneof" {
+		var options = strings.Fields(parameter)
+		if slices.Contains(
+			options,
+			fmt.Sprint(value),
+		) {
+			return ""
+		}
+		return fmt.Sprintf(
+			"must be one of [%v]",
+			strings.Join(options, ", "),
+		)
+	}
+	var limit, limitError = strconv.ParseFloat(
+		parameter,
+		64,
+	)
+	var measure, isLength, isMeasurable = getValidationMeasure(
+		value,
+	)
+	if limitError != nil || !isMeasurable {
+		return ""
+	}
+	var subject = "value"
+	if isLength {
+		subject = "length"
+	}
+	switch name {
+	case "min":
+		if measure < limit {
+			return fmt.Sprintf("%v must be at least %v", subject, parameter)
+		}
+	case "max":
+		if measure > limit {
+			return fmt.Sprintf("%v must be at most %v", subject, parameter)
+		}
+	case "len":
+		if measure != limit {
+			return fmt.Sprintf("%v must be exactly %v", subject, parameter)
+		}
+	}
+	return ""
+}
+
+// validateRequestData validates the unmarshalled request data through customization, combining all violations found into one bad request error
+func validateRequestData(session *session, category string, dataTemplate any) error {
+	var violations = session.customization.ValidateRequest(
+		session,
+		dataTemplate,
+	)
+	if len(violations) == 0 {
+		return nil
+	}
+	logEndpointRequest(
+		session,
+		category,
+		"ValidationError",
+		"%+v",
+		errors.Join(violations...),
+	)
+	var innerErrors []error
+	for _, violation := range violations {
+		if violation == nil {
+			continue
+		}
+		var _, isAppError = violation.(*appError)
+		if !isAppError {
+			violation = newAppError(
+				errorCodeBadRequest,
+				violation.Error(),
+			)
+		}
+		innerErrors = append(
+			innerErrors,
+			violation,
+		)
+	}
+	return newAppError(
+		errorCodeBadRequest,
+		errorMessageRequestValidationFailed,
+		innerErrors...,
+	)
+}
@@ -0,0 +1,220 @@
+package webserver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type dummyValidationItem struct {
+	Code string `json:"code" validate:"len=3"`
+}
+
+type dummyValidationEmbedded struct {
+	Kind string `json:"kind" validate:"oneof=a b"`
+}
+
+type dummyValidationTemplate struct {
+	dummyValidationEmbedded
+	Name     string                         `json:"name,omitempty" validate:"required,max=5"`
+	Age      int                            `validate:"min=18,max=60"`
+	Score    *float64                       `json:"score" validate:"omitempty,max=1.5"`
+	Level    uint                           `json:"-" validate:"max=3"`
+	Items    []dummyValidationItem          `json:"items" validate:"min=1"`
+	Lookup   map[string]dummyValidationItem `json:"lookup"`
+	Nested   *dummyValidationItem           `json:"nested"`
+	Ignored  string                         `validate:"-"`
+	Loose    any                            `json:"loose"`
+	internal string                         `validate:"required"`
+}
+
+func TestValidateStruct_Valid(t *testing.T) {
+	// arrange
+	var dummyDataTemplate = &dummyValidationTemplate{
+		dummyValidationEmbedded: dummyValidationEmbedded{
+			Kind: "a",
+		},
+		Name:  "some",
+		Age:   18,
+		Items: []dummyValidationItem{{Code: "abc"}},
+	}
+
+	// SUT + act
+	var result = ValidateStruct(
+		dummyDataTemplate,
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestValidateStruct_Violations(t *testing.T) {
+	// arrange
+	var dummyScore = 2.5
+	var dummyDataTemplate = &dummyValidationTemplate{
+		dummyValidationEmbedded: dummyValidationEmbedded{
+			Kind: "c",
+		},
+		Age:    61,
+		Score:  &dummyScore,
+		Level:  4,
+		Items:  []dummyValidationItem{{Code: "abc"}, {Code: "ab"}},
+		Lookup: map[string]dummyValidationItem{"foo": {Code: "abcd"}},
+		Nested: &dummyValidationItem{Code: "a"},
+		Loose:  dummyValidationItem{Code: "abcde"},
+	}
+
+	// SUT + act
+	var result = ValidateStruct(
+		dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, []error{
+		errors.New("field [kind] must be one of [a, b]"),
+		errors.New("field [name] is required"),
+		errors.New("field [Age] value must be at most 60"),
+		errors.New("field [score] value must be at most 1.5"),
+		errors.New("field [Level] value must be at most 3"),
+		errors.New("field [items[1].code] length must be exactly 3"),
+		errors.New("field [lookup[foo].code] length must be exactly 3"),
+		errors.New("field [nested.code] length must be exactly 3"),
+		errors.New("field [loose.code] length must be exactly 3"),
+	}, result)
+}
+
+func TestValidateStruct_NonStruct(t *testing.T) {
+	// arrange
+	var dummyDataTemplate = []int{1, 2, 3}
+
+	// SUT + act
+	var result = ValidateStruct(
+		&dummyDataTemplate,
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestValidateField_EmptyRules(t *testing.T) {
+	// arrange
+	var dummyValue = reflect.ValueOf("a")
+
+	// SUT + act
+	var result = validateField(
+		"some path",
+		dummyValue,
+		"omitempty,,min=1",
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestValidateField_NilPointer(t *testing.T) {
+	// arrange
+	var dummyPointer *int
+	var dummyValue = reflect.ValueOf(dummyPointer)
+
+	// SUT + act
+	var result = validateField(
+		"some path",
+		dummyValue,
+		"min=1",
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestValidateField_UnsupportedRules(t *testing.T) {
+	// arrange
+	var dummyValue = reflect.ValueOf(true)
+
+	// SUT + act
+	var result = validateField(
+		"some path",
+		dummyValue,
+		"min=1, foo=bar, email, gt=0",
+	)
+
+	// assert
+	assert.Empty(t, result)
+}
+
+func TestCheckValidationRule(t *testing.T) {
+	// assert
+	assert.Empty(t, checkValidationRule("oneof", "1 2", reflect.ValueOf(int8(2))))
+	assert.Equal(t, "length must be at least 2", checkValidationRule("min", "2", reflect.ValueOf("é")))
+	assert.Empty(t, checkValidationRule("min", "2", reflect.ValueOf(map[int]int{1: 1, 2: 2})))
+	assert.Equal(t, "value must be at least 2", checkValidationRule("min", "2", reflect.ValueOf(uint8(1))))
+	assert.Empty(t, checkValidationRule("max", "2", reflect.ValueOf([2]int{})))
+	assert.Empty(t, checkValidationRule("len", "2", reflect.ValueOf(float32(2))))
+	assert.Empty(t, checkValidationRule("foo", "2", reflect.ValueOf(2)))
+	assert.Empty(t, checkValidationRule("email", "", reflect.ValueOf("abc")))
+	assert.Empty(t, checkValidationRule("max", "abc", reflect.ValueOf(2)))
+}
+
+func TestValidateRequestData_Valid(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyCategory = "some category"
+	var dummyDataTemplate = "some data template"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).ValidateRequest).Expects(dummyCustomization, dummySession, dummyDataTemplate).Returns([]error{}).Once()
+
+	// SUT + act
+	var err = validateRequestData(
+		dummySession,
+		dummyCategory,
+		dummyDataTemplate,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestValidateRequestData_Violations(t *testing.T) {
+	// arrange
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		customization: dummyCustomization,
+	}
+	var dummyCategory = "some category"
+	var dummyDataTemplate = "some data template"
+	var dummyViolation1 = errors.New("some violation 1")
+	var dummyViolation2 = GetUnauthorized("some violation 2")
+	var dummyViolations = []error{
+		dummyViolation1,
+		nil,
+		dummyViolation2,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*DefaultCustomization).ValidateRequest).Expects(dummyCustomization, dummySession, dummyDataTemplate).Returns(dummyViolations).Once()
+	m.Mock(logEndpointRequest).Expects(dummySession, dummyCategory, "ValidationError", "%+v", errors.Join(dummyViolations...)).Returns().Once()
+
+	// SUT + act
+	var err = validateRequestData(
+		dummySession,
+		dummyCategory,
+		dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, "(BadRequest) The request validation failed -> [ (BadRequest) some violation 1 | (Unauthorized) some violation 2 ]", err.Error())
+	assert.Equal(t, 400, err.(AppError).HTTPStatusCode())
+}
@@ -0,0 +1,106 @@
+package webserver
+
+import (
+	"bytes"
+	"io"
+	"maps"
+	"mime/multipart"
+	"slices"
+)
+
+// formFile holds a file registered by AddFormFile to be sent as part of a multipart form payload
+type formFile struct {
+	fieldName string
+	fileName  string
+	content   io.Reader
+}
+
+func setContentType(webRequest *webRequest, contentType string) {
+	if webRequest.header == nil {
+		webRequest.header = make(map[string][]string)
+	}
+	webRequest.header["Content-Type"] = []string{
+		contentType,
+	}
+}
+
+func writeMultipartForm(webRequest *webRequest, buffer *bytes.Buffer) (string, error) {
+	var writer = multipart.NewWriter(
+		buffer,
+	)
+	var fieldNames = slices.Sorted(
+		maps.Keys(
+			webRequest.formValues,
+		),
+	)
+	for _, fieldName := range fieldNames {
+		for _, value := range webRequest.formValues[fieldName] {
+			var fieldError = writer.WriteField(
+				fieldName,
+				value,
+			)
+			if fieldError != nil {
+				return "", fieldError
+			}
+		}
+	}
+	for _, formFile := range webRequest.formFiles {
+		var part, partError = writer.CreateFormFile(
+			formFile.fieldName,
+			formFile.fileName,
+		)
+		if partError != nil {
+			return "", partError
+		}
+		var _, copyError = io.Copy(
+			part,
+			formFile.content,
+		)
+		if copyError != nil {
+			return "", copyError
+		}
+	}
+	var closeError = writer.Close()
+	if closeError != nil {
+		return "", closeError
+	}
+	return writer.FormDataContentType(), nil
+}
+
+// prepareWebcallPayload surfaces payload building errors, rejects resending an already sent payload stream, and generates the multipart form payload when form files are added
+func prepareWebcallPayload(webRequest *webRequest) error {
+	if webRequest.payloadError != nil {
+		return webRequest.payloadError
+	}
+	if webRequest.payloadStream != nil {
+		if webRequest.payloadStreamSent {
+			return newAppError(
+				errorCodeGeneralFailure,
+				errorMessageRequestBodyNotReplayable,
+			)
+		}
+		webRequest.payloadStreamSent = true
+	}
+	if len(webRequest.formFiles) == 0 {
+		return nil
+	}
+	var buffer = &bytes.Buffer{}
+	var contentType, formError = writeMultipartForm(
+		webRequest,
+		buffer,
+	)
+	if formError != nil {
+		return newAppError(
+			errorCodeGeneralFailure,
+			errorMessageWebcallPayloadInvalid,
+			formError,
+		)
+	}
+	webRequest.payload = buffer.String()
+	webRequest.formFiles = nil
+	setContentType(
+		webRequest,
+		contentType,
+	)
+	return nil
+}
@@ -0,0 +1,311 @@
+package webserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+type errorReader struct {
+	err error
+}
+
+func (reader *errorReader) Read(data []byte) (int, error) {
+	return 0, reader.err
+}
+
+func TestSetContentType_NilHeader(t *testing.T) {
+	// arrange
+	var dummyContentType = "some content type"
+	var dummyWebRequest = &webRequest{}
+
+	// SUT + act
+	setContentType(
+		dummyWebRequest,
+		dummyContentType,
+	)
+
+	// assert
+	assert.Equal(t, map[string][]string{"Content-Type": {dummyContentType}}, dummyWebRequest.header)
+}
+
+func TestSetContentType_ExistingHeader(t *testing.T) {
+	// arrange
+	var dummyContentType = "some content type"
+	var dummyWebRequest = &webRequest{
+		header: map[string][]string{
+			"foo":          {"bar"},
+			"Content-Type": {"some old content type"},
+		},
+	}
+
+	// SUT + act
+	setContentType(
+		dummyWebRequest,
+		dummyContentType,
+	)
+
+	// assert
+	assert.Equal(t, map[string][]string{"foo": {"bar"}, "Content-Type": {dummyContentType}}, dummyWebRequest.header)
+}
+
+func TestWriteMultipartForm_FieldError(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		formValues: url.Values{"foo": {"bar"}},
+	}
+	var dummyBuffer = &bytes.Buffer{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*multipart.Writer).WriteField).Expects(gomocker.Anything(), "foo", "bar").Returns(dummyError).Once()
+
+	// SUT + act
+	var result, err = writeMultipartForm(
+		dummyWebRequest,
+		dummyBuffer,
+	)
+
+	// assert
+	assert.Empty(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteMultipartForm_PartError(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		formFiles: []formFile{{"some field", "some file", strings.NewReader("some content")}},
+	}
+	var dummyBuffer = &bytes.Buffer{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*multipart.Writer).CreateFormFile).Expects(gomocker.Anything(), "some field", "some file").Returns(nil, dummyError).Once()
+
+	// SUT + act
+	var result, err = writeMultipartForm(
+		dummyWebRequest,
+		dummyBuffer,
+	)
+
+	// assert
+	assert.Empty(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteMultipartForm_CopyError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyWebRequest = &webRequest{
+		formFiles: []formFile{{"some field", "some file", &errorReader{dummyError}}},
+	}
+	var dummyBuffer = &bytes.Buffer{}
+
+	// SUT + act
+	var result, err = writeMultipartForm(
+		dummyWebRequest,
+		dummyBuffer,
+	)
+
+	// assert
+	assert.Empty(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteMultipartForm_CloseError(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{}
+	var dummyBuffer = &bytes.Buffer{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*multipart.Writer).Close).Expects(gomocker.Anything()).Returns(dummyError).Once()
+
+	// SUT + act
+	var result, err = writeMultipartForm(
+		dummyWebRequest,
+		dummyBuffer,
+	)
+
+	// assert
+	assert.Empty(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestWriteMultipartForm_Success(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		formValues: url.Values{
+			"test": {"1", "2"},
+			"foo":  {"bar"},
+		},
+		formFiles: []formFile{
+			{"some field", "some file", strings.NewReader("some content")},
+		},
+	}
+	var dummyBuffer = &bytes.Buffer{}
+
+	// SUT + act
+	var result, err = writeMultipartForm(
+		dummyWebRequest,
+		dummyBuffer,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	var mediaType, params, parseError = mime.ParseMediaType(result)
+	assert.NoError(t, parseError)
+	assert.Equal(t, "multipart/form-data", mediaType)
+	var form, formError = multipart.NewReader(dummyBuffer, params["boundary"]).ReadForm(1024)
+	assert.NoError(t, formError)
+	assert.Equal(t, map[string][]string{"foo": {"bar"}, "test": {"1", "2"}}, form.Value)
+	assert.Equal(t, 1, len(form.File["some field"]))
+	assert.Equal(t, "some file", form.File["some field"][0].Filename)
+	var file, _ = form.File["some field"][0].Open()
+	var content, _ = io.ReadAll(file)
+	assert.Equal(t, "some content", string(content))
+}
+
+func TestPrepareWebcallPayload_PayloadError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyWebRequest = &webRequest{
+		payloadError: dummyError,
+	}
+
+	// SUT + act
+	var err = prepareWebcallPayload(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
+func TestPrepareWebcallPayload_StreamAlreadySent(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		payloadStream:     &streamCounter{},
+		payloadStreamSent: true,
+	}
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageRequestBodyNotReplayable).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = prepareWebcallPayload(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestPrepareWebcallPayload_StreamFirstSent(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		payloadStream: &streamCounter{},
+	}
+
+	// SUT + act
+	var err = prepareWebcallPayload(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, dummyWebRequest.payloadStreamSent)
+}
+
+func TestPrepareWebcallPayload_NoFormFiles(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyWebRequest = &webRequest{
+		payload: dummyPayload,
+	}
+
+	// SUT + act
+	var err = prepareWebcallPayload(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyPayload, dummyWebRequest.payload)
+}
+
+func TestPrepareWebcallPayload_FormError(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyWebRequest = &webRequest{
+		payload:   dummyPayload,
+		formFiles: []formFile{{}},
+	}
+	var dummyError = errors.New("some error")
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(writeMultipartForm).Expects(dummyWebRequest, gomocker.Anything()).Returns("", dummyError).Once()
+	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageWebcallPayloadInvalid, dummyError).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = prepareWebcallPayload(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+	assert.Equal(t, dummyPayload, dummyWebRequest.payload)
+}
+
+func TestPrepareWebcallPayload_Success(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		payload:   "some payload",
+		formFiles: []formFile{{}},
+	}
+	var dummyContentType = "some content type"
+	var dummyContent = "some content"
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(writeMultipartForm).Expects(dummyWebRequest, gomocker.Anything()).Returns(dummyContentType, nil).SideEffects(
+		gomocker.ParamSideEffect(1, 2, func(value *bytes.Buffer) { value.WriteString(dummyContent) })).Once()
+	m.Mock(setContentType).Expects(dummyWebRequest, dummyContentType).Returns().Once()
+
+	// SUT + act
+	var err = prepareWebcallPayload(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyContent, dummyWebRequest.payload)
+	assert.Nil(t, dummyWebRequest.formFiles)
+}
@@ -2,9 +2,13 @@ package webserver
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -28,21 +32,104 @@ func getClientForRequest(sendClientCert bool) *http.Client {
 	return httpClientNoCert
 }
 
+func waitForRetry(
+	requestContext context.Context,
+	retryDelay time.Duration,
+) error {
+	var timer = time.NewTimer(
+		retryDelay,
+	)
+	defer timer.Stop()
+	select {
+	case <-requestContext.Done():
+		return requestContext.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func logRetryAttempt(
+	session *session,
+	attempt int,
+	responseObject *http.Response,
+	responseError error,
+	attemptTime time.Time,
+) {
+	if responseError != nil {
+		logWebcallFinish(
+			session,
+			"Error",
+			"-1",
+			"Attempt #%d failed after %s: %+v",
+			attempt,
+			time.Since(attemptTime),
+			responseError,
+		)
+		return
+	}
+	logWebcallFinish(
+		session,
+		http.StatusText(responseObject.StatusCode),
+		strconv.Itoa(responseObject.StatusCode),
+		"Attempt #%d failed after %s",
+		attempt,
+		time.Since(attemptTime),
+	)
+	if responseObject.Body != nil {
+		responseObject.Body.Close()
+	}
+}
+
+// isRequestReplayable checks whether the request body could be sent again for a retry, i.e. it is either empty or recreatable
+func isRequestReplayable(httpRequest *http.Request) bool {
+	return httpRequest.GetBody != nil ||
+		httpRequest.Body == nil ||
+		httpRequest.Body == http.NoBody
+}
+
+func cloneHTTPRequest(
+	httpRequest *http.Request,
+) (*http.Request, error) {
+	var clonedRequest = httpRequest.Clone(
+		httpRequest.Context(),
+	)
+	if httpRequest.GetBody == nil {
+		return clonedRequest, nil
+	}
+	var requestBody, bodyError = httpRequest.GetBody()
+	if bodyError != nil {
+		return nil, bodyError
+	}
+	clonedRequest.Body = requestBody
+	return clonedRequest, nil
+}
+
 func clientDoWithRetry(
+	session *session,
 	httpClient *http.Client,
 	httpRequest *http.Request,
 	connectivityRetryCount int,
 	httpStatusRetryCount map[int]int,
-	retryDelay time.Duration,
+	retryPolicy RetryPolicy,
 ) (*http.Response, error) {
 	var responseObject *http.Response
 	var responseError error
-	for {
-		responseObject, responseError = httpClient.Do(
+	var startTime = getTimeNowUTC()
+	var retryDelay time.Duration
+	for attempt := 1; ; attempt++ {
+		var attemptRequest, cloneError = cloneHTTPRequest(
 			httpRequest,
 		)
+		if cloneError != nil {
+			return nil, cloneError
+		}
+		var attemptTime = getTimeNowUTC()
+		responseObject, responseError = httpClient.Do(
+			attemptRequest,
+		)
 		if responseError != nil {
-			if connectivityRetryCount <= 0 {
+			if connectivityRetryCount <= 0 ||
+				httpRequest.Context().Err() != nil {
 				break
 			}
 			connectivityRetryCount--
@@ -55,7 +142,45 @@ func clientDoWithRetry(
 		} else {
 			break
 		}
-		time.Sleep(
+		if !isRequestReplayable(httpRequest) {
+			logWebcallRequest(
+				session,
+				"Retry",
+				"Skipped",
+				"Request body cannot be replayed after attempt #%d",
+				attempt,
+			)
+			break
+		}
+		var nextDelay, proceed = retryPolicy.NextDelay(
+			attempt,
+			time.Since(startTime),
+			retryDelay,
+			responseObject,
+		)
+		if !proceed {
+			break
+		}
+		logRetryAttempt(
+			session,
+			attempt,
+			responseObject,
+			responseError,
+			attemptTime,
+		)
+		var waitError = waitForRetry(
+			httpRequest.Context(),
+			nextDelay,
+		)
+		if waitError != nil {
+			return nil, waitError
+		}
+		retryDelay = nextDelay
+		logWebcallStart(
+			session,
+			"Retry",
+			strconv.Itoa(attempt+1),
+			"Retrying after %s",
 			retryDelay,
 		)
 	}
@@ -120,10 +245,26 @@ type WebRequest interface {
 	AddHeader(name string, value string) WebRequest
 	// AddHeaders adds a set of headers to the request Header for sending through HTTP
 	AddHeaders(headers map[string]string) WebRequest
+	// SetJSONBody sets the payload to the JSON representation of the given body and the Content-Type header to application/json, replacing any previously set payload
+	SetJSONBody(body any) WebRequest
+	// SetFormBody sets the payload to the URL encoded form of the given values and the Content-Type header to application/x-www-form-urlencoded, replacing any previously set payload; when form files are added, the values are sent as multipart form fields instead
+	SetFormBody(values url.Values) WebRequest
+	// AddFormFile adds a file to be sent as part of a multipart form payload, with the Content-Type header set to multipart/form-data accordingly; the content is read when the webcall is processed
+	AddFormFile(fieldName string, fileName string, content io.Reader) WebRequest
 	// SetupRetry sets up automatic retry upon error of specific HTTP status codes; each entry maps an HTTP status code to how many times retry should happen if code matches
 	SetupRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int, retryDelay time.Duration) WebRequest
+	// SetupRetryPolicy sets up the policy deciding the delays between retries, e.g. a BackoffRetryPolicy for exponential backoff with jitter; overrides the fixed retry delay given to SetupRetry
+	SetupRetryPolicy(retryPolicy RetryPolicy) WebRequest
+	// WithContext sets the context to be used for sending the webcall request; if not set, the context of the session's HTTP request is used, so that the webcall gets cancelled together with the incoming request
+	WithContext(ctx context.Context) WebRequest
+	// WithTimeout sets the timeout for the whole webcall processing including retries, on top of the deadline of the webcall context if any
+	WithTimeout(timeout time.Duration) WebRequest
 	// Anticipate registers a data template to be deserialized to when the given range of HTTP status codes are returned during the processing of the web request; latter registration overrides former when overlapping; statusCodes can be either integers, or StatusCodeRange instances
 	Anticipate(dataTemplate any, statusCodes ...any) WebRequest
+	// AnticipateStream registers a handler to be given the response body as a stream, instead of deserializing it, when the given range of HTTP status codes are returned; the body is closed once the handler returns; statusCodes follow the same rules as Anticipate
+	AnticipateStream(handler func(body io.Reader) error, statusCodes ...any) WebRequest
+	// AnticipateWriter registers a writer for the response body to be copied to as a stream when the given range of HTTP status codes are returned; statusCodes follow the same rules as Anticipate
+	AnticipateWriter(writer io.Writer, statusCodes ...any) WebRequest
 	// Process sends the webcall request over the wire, retrieves and serialize the response to registered data templates, and returns status code, header and error accordingly
 	Process() (statusCode int, responseHeader http.Header, responseError error)
 }
@@ -134,17 +275,26 @@ type dataReceiver struct {
 }
 
 type webRequest struct {
-	session        *session
-	method         string
-	url            string
-	payload        string
-	query          map[string][]string
-	header         map[string][]string
-	connRetry      int
-	httpRetry      map[int]int
-	sendClientCert bool
-	retryDelay     time.Duration
-	dataReceivers  []dataReceiver
+	session           *session
+	method            string
+	url               string
+	payload           string
+	query             map[string][]string
+	header            map[string][]string
+	connRetry         int
+	httpRetry         map[int]int
+	sendClientCert    bool
+	retryDelay        time.Duration
+	dataReceivers     []dataReceiver
+	ctx               context.Context
+	timeout           time.Duration
+	retryPolicy       RetryPolicy
+	payloadStream     *streamCounter
+	payloadStreamSent bool
+	formValues        url.Values
+	formFiles         []formFile
+	payloadError      error
+	responseReceived  bool
 }
 
 // AddQuery adds a query to the request URL for sending through HTTP
@@ -207,6 +357,58 @@ func (webRequest *webRequest) AddHeaders(headers map[string]string) WebRequest {
 	return webRequest
 }
 
+// SetJSONBody sets the payload to the JSON representation of the given body and the Content-Type header to application/json, replacing any previously set payload
+func (webRequest *webRequest) SetJSONBody(body any) WebRequest {
+	var bodyBytes, marshalError = json.Marshal(
+		body,
+	)
+	if marshalError != nil {
+		webRequest.payloadError = newAppError(
+			errorCodeGeneralFailure,
+			errorMessageWebcallPayloadInvalid,
+			marshalError,
+		)
+		return webRequest
+	}
+	webRequest.payload = string(bodyBytes)
+	webRequest.payloadStream = nil
+	webRequest.formValues = nil
+	webRequest.formFiles = nil
+	webRequest.payloadError = nil
+	setContentType(
+		webRequest,
+		ContentTypeJSON,
+	)
+	return webRequest
+}
+
+// SetFormBody sets the payload to the URL encoded form of the given values and the Content-Type header to application/x-www-form-urlencoded, replacing any previously set payload; when form files are added, the values are sent as multipart form fields instead
+func (webRequest *webRequest) SetFormBody(values url.Values) WebRequest {
+	webRequest.payload = values.Encode()
+	webRequest.payloadStream = nil
+	webRequest.formValues = values
+	webRequest.payloadError = nil
+	setContentType(
+		webRequest,
+		ContentTypeForm,
+	)
+	return webRequest
+}
+
+// AddFormFile adds a file to be sent as part of a multipart form payload, with the Content-Type header set to multipart/form-data accordingly; the content is read when the webcall is processed
+func (webRequest *webRequest) AddFormFile(fieldName string, fileName string, content io.Reader) WebRequest {
+	webRequest.payloadStream = nil
+	webRequest.formFiles = append(
+		webRequest.formFiles,
+		formFile{
+			fieldName: fieldName,
+			fileName:  fileName,
+			content:   content,
+		},
+	)
+	return webRequest
+}
+
 // SetupRetry sets up automatic retry upon error of specific HTTP status codes; each entry maps an HTTP status code to how many times retry should happen if code matches; 0 stands for error not mapped to an HTTP status code, e.g. webcall or connectivity issue
 func (webRequest *webRequest) SetupRetry(connectivityRetryCount int, httpStatusRetryCount map[int]int, retryDelay time.Duration) WebRequest {
 	webRequest.connRetry = connectivityRetryCount
@@ -215,6 +417,24 @@ func (webRequest *webRequest) SetupRetry(connectivityRetryCount int, httpStatusR
 	return webRequest
 }
 
+// SetupRetryPolicy sets up the policy deciding the delays between retries, e.g. a BackoffRetryPolicy for exponential backoff with jitter; overrides the fixed retry delay given to SetupRetry
+func (webRequest *webRequest) SetupRetryPolicy(retryPolicy RetryPolicy) WebRequest {
+	webRequest.retryPolicy = retryPolicy
+	return webRequest
+}
+
+// WithContext sets the context to be used for sending the webcall request; if not set, the context of the session's HTTP request is used, so that the webcall gets cancelled together with the incoming request
+func (webRequest *webRequest) WithContext(ctx context.Context) WebRequest {
+	webRequest.ctx = ctx
+	return webRequest
+}
+
+// WithTimeout sets the timeout for the whole webcall processing including retries, on top of the deadline of the webcall context if any
+func (webRequest *webRequest) WithTimeout(timeout time.Duration) WebRequest {
+	webRequest.timeout = timeout
+	return webRequest
+}
+
 // Anticipate registers a data template to be deserialized to when the given range of HTTP status codes are returned during the processing of the web request; latter registration overrides former when overlapping
 func (webRequest *webRequest) Anticipate(dataTemplate any, statusCodes ...any) WebRequest {
 	if len(statusCodes) == 0 {
@@ -263,6 +483,30 @@ func (webRequest *webRequest) Anticipate(dataTemplate any, statusCodes ...any) W
 	return webRequest
 }
 
+// AnticipateStream registers a handler to be given the response body as a stream, instead of deserializing it, when the given range of HTTP status codes are returned; the body is closed once the handler returns; statusCodes follow the same rules as Anticipate
+func (webRequest *webRequest) AnticipateStream(handler func(body io.Reader) error, statusCodes ...any) WebRequest {
+	return webRequest.Anticipate(
+		&streamReceiver{
+			handler: handler,
+		},
+		statusCodes...,
+	)
+}
+
+// AnticipateWriter registers a writer for the response body to be copied to as a stream when the given range of HTTP status codes are returned; statusCodes follow the same rules as Anticipate
+func (webRequest *webRequest) AnticipateWriter(writer io.Writer, statusCodes ...any) WebRequest {
+	return webRequest.AnticipateStream(
+		func(body io.Reader) error {
+			var _, copyError = io.Copy(
+				writer,
+				body,
+			)
+			return copyError
+		},
+		statusCodes...,
+	)
+}
+
 func createQueryString(
 	query map[string][]string,
 ) string {
@@ -308,7 +552,33 @@ func generateRequestURL(
 	)
 }
 
-func createHTTPRequest(webRequest *webRequest) (*http.Request, error) {
+func getRequestContext(webRequest *webRequest) (context.Context, context.CancelFunc) {
+	var requestContext = webRequest.ctx
+	if requestContext == nil {
+		requestContext = webRequest.session.GetRequest().Context()
+	}
+	var cancelCallback context.CancelFunc
+	if webRequest.timeout <= 0 {
+		requestContext, cancelCallback = context.WithCancel(
+			requestContext,
+		)
+	} else {
+		requestContext, cancelCallback = context.WithTimeout(
+			requestContext,
+			webRequest.timeout,
+		)
+	}
+	var stopForceClose = context.AfterFunc(
+		getWebcallsContext(),
+		cancelCallback,
+	)
+	return requestContext, func() {
+		stopForceClose()
+		cancelCallback()
+	}
+}
+
+func createHTTPRequest(webRequest *webRequest, requestContext context.Context) (*http.Request, error) {
 	if webRequest == nil ||
 		webRequest.session == nil {
 		return nil,
@@ -317,14 +587,21 @@ func createHTTPRequest(webRequest *webRequest) (*http.Request, error) {
 				errorMessageWebRequestNil,
 			)
 	}
+	var payloadError = prepareWebcallPayload(
+		webRequest,
+	)
+	if payloadError != nil {
+		return nil, payloadError
+	}
 	var requestURL = generateRequestURL(
 		webRequest.url,
 		webRequest.query,
 	)
-	var requestBody = strings.NewReader(
-		webRequest.payload,
+	var requestBody = getWebcallRequestBody(
+		webRequest,
 	)
-	var requestObject, requestError = http.NewRequest(
+	var requestObject, requestError = http.NewRequestWithContext(
+		requestContext,
 		webRequest.method,
 		requestURL,
 		requestBody,
@@ -339,12 +616,8 @@ func createHTTPRequest(webRequest *webRequest) (*http.Request, error) {
 		"%s",
 		requestURL,
 	)
-	logWebcallRequest(
-		webRequest.session,
-		"Payload",
-		"Content",
-		"%s",
-		webRequest.payload,
+	logRequestPayload(
+		webRequest,
 	)
 	requestObject.Header = make(http.Header)
 	for name, values := range webRequest.header {
@@ -352,6 +625,10 @@ func createHTTPRequest(webRequest *webRequest) (*http.Request, error) {
 			requestObject.Header.Add(name, value)
 		}
 	}
+	injectTraceContext(
+		webRequest.session,
+		requestObject,
+	)
 	logWebcallRequest(
 		webRequest.session,
 		"Header",
@@ -424,7 +701,34 @@ func logSuccessResponse(session *session, response *http.Response, startTime tim
 	)
 }
 
-func doRequestProcessing(webRequest *webRequest) (*http.Response, error) {
+func getCancellationError(responseError error) error {
+	if errors.Is(responseError, context.DeadlineExceeded) {
+		return newAppError(
+			errorCodeRequestTimeout,
+			errorMessageWebcallTimeout,
+			responseError,
+		)
+	}
+	if errors.Is(responseError, context.Canceled) {
+		return newAppError(
+			errorCodeRequestCancelled,
+			errorMessageWebcallCancelled,
+			responseError,
+		)
+	}
+	return responseError
+}
+
+func getRetryPolicy(webRequest *webRequest) RetryPolicy {
+	if !isInterfaceValueNil(webRequest.retryPolicy) {
+		return webRequest.retryPolicy
+	}
+	return &BackoffRetryPolicy{
+		InitialDelay: webRequest.retryDelay,
+	}
+}
+
+func doRequestProcessing(webRequest *webRequest, requestContext context.Context) (*http.Response, error) {
 	if webRequest == nil ||
 		webRequest.session == nil {
 		return nil,
@@ -435,31 +739,77 @@ func doRequestProcessing(webRequest *webRequest) (*http.Response, error) {
 	}
 	var requestObject, requestError = createHTTPRequest(
 		webRequest,
+		requestContext,
 	)
 	if requestError != nil {
 		return nil, requestError
 	}
+	var startTime = getTimeNowUTC()
+	var circuitSetting = webRequest.session.customization.CircuitBreaker(
+		webRequest.session,
+		requestObject,
+	)
+	var circuitError = allowCircuitRequest(
+		webRequest.session,
+		circuitSetting,
+		requestObject,
+	)
+	if circuitError != nil {
+		logErrorResponse(
+			webRequest.session,
+			circuitError,
+			startTime,
+		)
+		return nil, circuitError
+	}
 	var httpClient = getClientForRequest(
 		webRequest.sendClientCert,
 	)
 	var responseObject, responseError = clientDoWithRetry(
+		webRequest.session,
 		httpClient,
 		requestObject,
 		webRequest.connRetry,
-		webRequest.httpRetry,
-		webRequest.retryDelay,
+		maps.Clone(
+			webRequest.httpRetry,
+		),
+		getRetryPolicy(
+			webRequest,
+		),
+	)
+	logStreamedPayload(
+		webRequest,
+	)
+	recordCircuitResult(
+		webRequest.session,
+		circuitSetting,
+		requestObject,
+		responseObject,
+		responseError,
 	)
 	if responseError != nil {
+		responseError = getCancellationError(
+			responseError,
+		)
 		logErrorResponse(
 			webRequest.session,
 			responseError,
-			getTimeNowUTC(),
+			startTime,
+		)
+	} else if isStreamResponse(
+		responseObject,
+		webRequest.dataReceivers,
+	) {
+		logStreamResponse(
+			webRequest.session,
+			responseObject,
+			startTime,
 		)
 	} else {
 		logSuccessResponse(
 			webRequest.session,
 			responseObject,
-			getTimeNowUTC(),
+			startTime,
 		)
 	}
 	return webRequest.session.customization.WrapResponse(
@@ -499,6 +849,14 @@ func parseResponse(session *session, body io.ReadCloser, dataTemplate any) error
 		)
 		return nil
 	}
+	var receiver, isStream = dataTemplate.(*streamReceiver)
+	if isStream {
+		return consumeResponseStream(
+			session,
+			body,
+			receiver,
+		)
+	}
 	var bodyBytes, bodyError = io.ReadAll(
 		body,
 	)
@@ -526,27 +884,21 @@ func parseResponse(session *session, body io.ReadCloser, dataTemplate any) error
 	return nil
 }
 
-// Process sends the webcall request over the wire, retrieves and serialize the response to dataTemplate, and provides status code, header and error if applicable
-func (webRequest *webRequest) Process() (statusCode int, responseHeader http.Header, responseError error) {
-	if webRequest == nil ||
-		webRequest.session == nil {
-		return http.StatusInternalServerError,
-			http.Header{},
-			newAppError(
-				errorCodeGeneralFailure,
-				errorMessageWebRequestNil,
-			)
-	}
-	var responseObject *http.Response
-	responseObject, responseError = doRequestProcessing(
+func processWebRequest(webRequest *webRequest, requestContext context.Context) (int, http.Header, error) {
+	var responseObject, responseError = doRequestProcessing(
 		webRequest,
+		requestContext,
 	)
+	webRequest.responseReceived = responseObject != nil
 	if responseError != nil {
 		if responseObject == nil {
 			return http.StatusInternalServerError,
 				make(http.Header),
 				responseError
 		}
+		if responseObject.Body != nil {
+			responseObject.Body.Close()
+		}
 	} else {
 		if responseObject == nil {
 			logWebcallResponse(
@@ -572,3 +924,75 @@ func (webRequest *webRequest) Process() (statusCode int, responseHeader http.Hea
 		responseObject.Header,
 		responseError
 }
+
+// Process sends the webcall request over the wire, retrieves and serialize the response to dataTemplate, and provides status code, header and error if applicable
+func (webRequest *webRequest) Process() (statusCode int, responseHeader http.Header, responseError error) {
+	if webRequest == nil ||
+		webRequest.session == nil {
+		return http.StatusInternalServerError,
+			http.Header{},
+			newAppError(
+				errorCodeGeneralFailure,
+				errorMessageWebRequestNil,
+			)
+	}
+	var requestContext, cancelCallback = getRequestContext(
+		webRequest,
+	)
+	defer cancelCallback()
+	var spanContext, span = startClientSpan(
+		webRequest,
+		requestContext,
+	)
+	var startTime = getTimeNowUTC()
+	statusCode, responseHeader, responseError = processWebRequest(
+		webRequest,
+		spanContext,
+	)
+	var duration = time.Since(startTime)
+	finishClientTelemetry(
+		webRequest,
+		spanContext,
+		span,
+		duration,
+		statusCode,
+		responseError,
+	)
+	recordWebcallMetrics(
+		webRequest,
+		duration,
+		statusCode,
+	)
+	return statusCode, responseHeader, responseError
+}
+
+// ProcessAs is a sugar-function to process the webcall request and retrieve the response body as objects via generics; 2xx responses are decoded into T and all others into E, with the other one returned as nil, while both are nil if no response is received at all, e.g. upon connectivity errors, cancellation or open circuit; the data templates registered on the webcall request are kept as before, so the webcall request could still be reused afterwards
+func ProcessAs[T, E any](webcallRequest WebRequest) (*T, *E, int, http.Header, error) {
+	var request, isWebRequest = webcallRequest.(*webRequest)
+	if isWebRequest {
+		var dataReceivers = request.dataReceivers
+		defer func() {
+			request.dataReceivers = dataReceivers
+		}()
+	}
+	var result T
+	var failure E
+	var statusCode, responseHeader, responseError = webcallRequest.Anticipate(
+		&failure,
+	).Anticipate(
+		&result,
+		StatusCodeRange{
+			Begin: http.StatusOK,
+			End:   http.StatusMultipleChoices,
+		},
+	).Process()
+	if isWebRequest &&
+		!request.responseReceived {
+		return nil, nil, statusCode, responseHeader, responseError
+	}
+	if statusCode >= http.StatusOK &&
+		statusCode < http.StatusMultipleChoices {
+		return &result, nil, statusCode, responseHeader, responseError
+	}
+	return nil, &failure, statusCode, responseHeader, responseError
+}
@@ -2,11 +2,15 @@ package webserver
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"math/rand/v2"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"strings"
 	"testing"
@@ -15,6 +19,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/zhongjie-cai/gomocker/v2"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestGetClientForRequest_UseCustomClient(t *testing.T) {
@@ -69,13 +74,192 @@ func TestGetClientForRequest_NoSendClientCert(t *testing.T) {
 	assert.Equal(t, dummyHTTPClient2, result)
 }
 
+func TestWaitForRetry_Elapsed(t *testing.T) {
+	// arrange
+	var dummyContext = context.Background()
+	var dummyRetryDelay = time.Duration(rand.IntN(100))
+
+	// SUT + act
+	var err = waitForRetry(
+		dummyContext,
+		dummyRetryDelay,
+	)
+
+	// assert
+	assert.NoError(t, err)
+}
+
+func TestWaitForRetry_ContextDone(t *testing.T) {
+	// arrange
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRetryDelay = time.Hour
+
+	// stub
+	dummyCancel()
+
+	// SUT + act
+	var err = waitForRetry(
+		dummyContext,
+		dummyRetryDelay,
+	)
+
+	// assert
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestLogRetryAttempt_ResponseError(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyAttempt = rand.IntN(100)
+	var dummyResponseObject *http.Response
+	var dummyResponseError = errors.New("some error")
+	var dummyAttemptTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.IntN(1000))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyTimeSince).Once()
+	m.Mock(logWebcallFinish).Expects(dummySession, "Error", "-1", "Attempt #%d failed after %s: %+v", dummyAttempt, dummyTimeSince, dummyResponseError).Returns().Once()
+
+	// SUT + act
+	logRetryAttempt(
+		dummySession,
+		dummyAttempt,
+		dummyResponseObject,
+		dummyResponseError,
+		dummyAttemptTime,
+	)
+}
+
+func TestLogRetryAttempt_ResponseObject(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyAttempt = rand.IntN(100)
+	var dummyStatusCode = rand.IntN(1000)
+	var dummyStatus = "some status"
+	var dummyBody = io.NopCloser(bytes.NewBufferString("some body"))
+	var dummyResponseObject = &http.Response{
+		StatusCode: dummyStatusCode,
+		Body:       dummyBody,
+	}
+	var dummyAttemptTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.IntN(1000))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(http.StatusText).Expects(dummyStatusCode).Returns(dummyStatus).Once()
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyTimeSince).Once()
+	m.Mock(logWebcallFinish).Expects(dummySession, dummyStatus, strconv.Itoa(dummyStatusCode), "Attempt #%d failed after %s", dummyAttempt, dummyTimeSince).Returns().Once()
+
+	// SUT + act
+	logRetryAttempt(
+		dummySession,
+		dummyAttempt,
+		dummyResponseObject,
+		nil,
+		dummyAttemptTime,
+	)
+}
+
+func TestIsRequestReplayable(t *testing.T) {
+	// arrange
+	var dummyGetBody = func() (io.ReadCloser, error) {
+		return http.NoBody, nil
+	}
+	var dummyBody = io.NopCloser(bytes.NewBufferString("some body"))
+
+	// SUT + act
+	var result1 = isRequestReplayable(&http.Request{})
+	var result2 = isRequestReplayable(&http.Request{Body: http.NoBody})
+	var result3 = isRequestReplayable(&http.Request{Body: dummyBody, GetBody: dummyGetBody})
+	var result4 = isRequestReplayable(&http.Request{Body: dummyBody})
+
+	// assert
+	assert.True(t, result1)
+	assert.True(t, result2)
+	assert.True(t, result3)
+	assert.False(t, result4)
+}
+
+func TestCloneHTTPRequest_NoGetBody(t *testing.T) {
+	// arrange
+	var dummyBody = io.NopCloser(bytes.NewBufferString("some body"))
+	var dummyHTTPRequest = &http.Request{
+		Method: "some method",
+		Body:   dummyBody,
+	}
+
+	// SUT + act
+	var result, err = cloneHTTPRequest(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NotSame(t, dummyHTTPRequest, result)
+	assert.Equal(t, dummyHTTPRequest.Method, result.Method)
+	assert.Equal(t, dummyBody, result.Body)
+	assert.NoError(t, err)
+}
+
+func TestCloneHTTPRequest_GetBodyError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+	var dummyHTTPRequest = &http.Request{
+		GetBody: func() (io.ReadCloser, error) {
+			return nil, dummyError
+		},
+	}
+
+	// SUT + act
+	var result, err = cloneHTTPRequest(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
+func TestCloneHTTPRequest_GetBodySuccess(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyHTTPRequest, _ = http.NewRequest(
+		http.MethodPost,
+		"http://localhost",
+		strings.NewReader(dummyPayload),
+	)
+	io.ReadAll(dummyHTTPRequest.Body)
+
+	// SUT + act
+	var result1, err1 = cloneHTTPRequest(
+		dummyHTTPRequest,
+	)
+	var result2, err2 = cloneHTTPRequest(
+		dummyHTTPRequest,
+	)
+
+	// assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	var body1, _ = io.ReadAll(result1.Body)
+	var body2, _ = io.ReadAll(result2.Body)
+	assert.Equal(t, dummyPayload, string(body1))
+	assert.Equal(t, dummyPayload, string(body2))
+}
+
 func TestClientDoWithRetry_ConnError_NoRetry(t *testing.T) {
 	// arrange
 	var dummyClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
 	var dummyConnRetry = 0
 	var dummyHTTPRetry = map[int]int{}
-	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
 	var dummyResponseObject = &http.Response{}
 	var dummyResponseError = errors.New("some error")
 
@@ -83,15 +267,18 @@ func TestClientDoWithRetry_ConnError_NoRetry(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Twice()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Once()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Once()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummySession,
 		dummyClient,
 		dummyRequestObject,
 		dummyConnRetry,
 		dummyHTTPRetry,
-		dummyRetryDelay,
+		dummyRetryPolicy,
 	)
 
 	// assert
@@ -105,6 +292,10 @@ func TestClientDoWithRetry_ConnError_RetryOK(t *testing.T) {
 	var dummyRequestObject = &http.Request{}
 	var dummyConnRetry = 2
 	var dummyHTTPRetry = map[int]int{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyElapsed = time.Duration(rand.IntN(100))
 	var dummyRetryDelay = time.Duration(rand.IntN(100))
 	var dummyResponseObject = &http.Response{}
 	var dummyResponseError = errors.New("some error")
@@ -113,17 +304,24 @@ func TestClientDoWithRetry_ConnError_RetryOK(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Times(3)
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyElapsed).Once()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Twice()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Once()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Once()
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Once()
+	m.Mock((*BackoffRetryPolicy).NextDelay).Expects(dummyRetryPolicy, 1, dummyElapsed, gomocker.Anything(), gomocker.Anything()).Returns(dummyRetryDelay, true).Once()
+	m.Mock(logRetryAttempt).Expects(dummySession, 1, gomocker.Anything(), gomocker.Anything(), dummyAttemptTime).Returns().Once()
+	m.Mock(waitForRetry).Expects(gomocker.Anything(), dummyRetryDelay).Returns(nil).Once()
+	m.Mock(logWebcallStart).Expects(dummySession, "Retry", "2", "Retrying after %s", dummyRetryDelay).Returns().Once()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummySession,
 		dummyClient,
 		dummyRequestObject,
 		dummyConnRetry,
 		dummyHTTPRetry,
-		dummyRetryDelay,
+		dummyRetryPolicy,
 	)
 
 	// assert
@@ -137,6 +335,10 @@ func TestClientDoWithRetry_ConnError_RetryFail(t *testing.T) {
 	var dummyRequestObject = &http.Request{}
 	var dummyConnRetry = 2
 	var dummyHTTPRetry = map[int]int{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyElapsed = time.Duration(rand.IntN(100))
 	var dummyRetryDelay = time.Duration(rand.IntN(100))
 	var dummyResponseObject = &http.Response{}
 	var dummyResponseError = errors.New("some error")
@@ -145,16 +347,23 @@ func TestClientDoWithRetry_ConnError_RetryFail(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Times(4)
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyElapsed).Twice()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Times(3)
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Times(3)
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Twice()
+	m.Mock((*BackoffRetryPolicy).NextDelay).Expects(dummyRetryPolicy, gomocker.Anything(), dummyElapsed, gomocker.Anything(), gomocker.Anything()).Returns(dummyRetryDelay, true).Twice()
+	m.Mock(logRetryAttempt).Expects(dummySession, gomocker.Anything(), gomocker.Anything(), gomocker.Anything(), dummyAttemptTime).Returns().Twice()
+	m.Mock(waitForRetry).Expects(gomocker.Anything(), dummyRetryDelay).Returns(nil).Twice()
+	m.Mock(logWebcallStart).Expects(dummySession, "Retry", gomocker.Anything(), "Retrying after %s", dummyRetryDelay).Returns().Twice()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummySession,
 		dummyClient,
 		dummyRequestObject,
 		dummyConnRetry,
 		dummyHTTPRetry,
-		dummyRetryDelay,
+		dummyRetryPolicy,
 	)
 
 	// assert
@@ -162,28 +371,189 @@ func TestClientDoWithRetry_ConnError_RetryFail(t *testing.T) {
 	assert.Equal(t, dummyResponseError, err)
 }
 
+func TestClientDoWithRetry_ConnError_ContextDone(t *testing.T) {
+	// arrange
+	var dummyClient = &http.Client{}
+	var dummyContext, dummyCancel = context.WithCancel(context.Background())
+	var dummyRequestObject = (&http.Request{}).WithContext(dummyContext)
+	var dummyConnRetry = 2
+	var dummyHTTPRetry = map[int]int{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyResponseObject *http.Response
+	var dummyResponseError = errors.New("some error")
+
+	// stub
+	dummyCancel()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Twice()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Once()
+	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Once()
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummySession,
+		dummyClient,
+		dummyRequestObject,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
+}
+
+func TestClientDoWithRetry_ConnError_PolicyStop(t *testing.T) {
+	// arrange
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyConnRetry = 2
+	var dummyHTTPRetry = map[int]int{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyElapsed = time.Duration(rand.IntN(100))
+	var dummyResponseObject *http.Response
+	var dummyResponseError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Twice()
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyElapsed).Once()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Once()
+	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock((*BackoffRetryPolicy).NextDelay).Expects(dummyRetryPolicy, 1, dummyElapsed, time.Duration(0), dummyResponseObject).Returns(time.Duration(0), false).Once()
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummySession,
+		dummyClient,
+		dummyRequestObject,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyResponseError, err)
+}
+
+func TestClientDoWithRetry_HTTPError_WaitError(t *testing.T) {
+	// arrange
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyConnRetry = rand.Int()
+	var dummyStatusCode = rand.Int()
+	var dummyHTTPRetry = map[int]int{
+		dummyStatusCode: 2,
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyElapsed = time.Duration(rand.IntN(100))
+	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummyResponseObject = &http.Response{
+		StatusCode: dummyStatusCode,
+	}
+	var dummyWaitError = errors.New("some wait error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Twice()
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyElapsed).Once()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Once()
+	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Once()
+	m.Mock((*BackoffRetryPolicy).NextDelay).Expects(dummyRetryPolicy, 1, dummyElapsed, time.Duration(0), dummyResponseObject).Returns(dummyRetryDelay, true).Once()
+	m.Mock(logRetryAttempt).Expects(dummySession, 1, dummyResponseObject, nil, dummyAttemptTime).Returns().Once()
+	m.Mock(waitForRetry).Expects(gomocker.Anything(), dummyRetryDelay).Returns(dummyWaitError).Once()
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummySession,
+		dummyClient,
+		dummyRequestObject,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyWaitError, err)
+	assert.Equal(t, 1, dummyHTTPRetry[dummyStatusCode])
+}
+
+func TestClientDoWithRetry_CloneError(t *testing.T) {
+	// arrange
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{}
+	var dummyConnRetry = rand.Int()
+	var dummyHTTPRetry = map[int]int{}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyCloneError = errors.New("some clone error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(time.Now()).Once()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(nil, dummyCloneError).Once()
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummySession,
+		dummyClient,
+		dummyRequestObject,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyCloneError, err)
+}
+
 func TestClientDoWithRetry_HTTPError_NilResponse(t *testing.T) {
 	// arrange
 	var dummyClient = &http.Client{}
 	var dummyRequestObject = &http.Request{}
 	var dummyConnRetry = rand.Int()
 	var dummyHTTPRetry = map[int]int{}
-	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
 	var dummyResponseObject *http.Response
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Twice()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Once()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Once()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummySession,
 		dummyClient,
 		dummyRequestObject,
 		dummyConnRetry,
 		dummyHTTPRetry,
-		dummyRetryDelay,
+		dummyRetryPolicy,
 	)
 
 	// assert
@@ -197,22 +567,27 @@ func TestClientDoWithRetry_HTTPError_NoRetry(t *testing.T) {
 	var dummyRequestObject = &http.Request{}
 	var dummyConnRetry = rand.Int()
 	var dummyHTTPRetry = map[int]int{}
-	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
 	var dummyResponseObject = &http.Response{}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Twice()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Once()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Once()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummySession,
 		dummyClient,
 		dummyRequestObject,
 		dummyConnRetry,
 		dummyHTTPRetry,
-		dummyRetryDelay,
+		dummyRetryPolicy,
 	)
 
 	// assert
@@ -220,6 +595,49 @@ func TestClientDoWithRetry_HTTPError_NoRetry(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestClientDoWithRetry_HTTPError_NotReplayable(t *testing.T) {
+	// arrange
+	var dummyClient = &http.Client{}
+	var dummyRequestObject = &http.Request{
+		Body: io.NopCloser(bytes.NewBufferString("some body")),
+	}
+	var dummyConnRetry = rand.Int()
+	var dummyStatusCode = rand.Int()
+	var dummyHTTPRetry = map[int]int{
+		dummyStatusCode: 2,
+	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyResponseObject = &http.Response{
+		StatusCode: dummyStatusCode,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Twice()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Once()
+	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Once()
+	m.Mock(logWebcallRequest).Expects(dummySession, "Retry", "Skipped", "Request body cannot be replayed after attempt #%d", 1).Returns().Once()
+
+	// SUT + act
+	var result, err = clientDoWithRetry(
+		dummySession,
+		dummyClient,
+		dummyRequestObject,
+		dummyConnRetry,
+		dummyHTTPRetry,
+		dummyRetryPolicy,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseObject, result)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dummyHTTPRetry[dummyStatusCode])
+}
+
 func TestClientDoWithRetry_HTTPError_RetryOK(t *testing.T) {
 	// arrange
 	var dummyClient = &http.Client{}
@@ -229,6 +647,10 @@ func TestClientDoWithRetry_HTTPError_RetryOK(t *testing.T) {
 	var dummyHTTPRetry = map[int]int{
 		dummyStatusCode: 2,
 	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyElapsed = time.Duration(rand.IntN(100))
 	var dummyRetryDelay = time.Duration(rand.IntN(100))
 	var dummyResponseObject1 = &http.Response{
 		StatusCode: dummyStatusCode,
@@ -239,17 +661,24 @@ func TestClientDoWithRetry_HTTPError_RetryOK(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Times(3)
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyElapsed).Once()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Twice()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject1, nil).Once()
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject2, nil).Once()
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Once()
+	m.Mock((*BackoffRetryPolicy).NextDelay).Expects(dummyRetryPolicy, 1, dummyElapsed, gomocker.Anything(), gomocker.Anything()).Returns(dummyRetryDelay, true).Once()
+	m.Mock(logRetryAttempt).Expects(dummySession, 1, gomocker.Anything(), gomocker.Anything(), dummyAttemptTime).Returns().Once()
+	m.Mock(waitForRetry).Expects(gomocker.Anything(), dummyRetryDelay).Returns(nil).Once()
+	m.Mock(logWebcallStart).Expects(dummySession, "Retry", "2", "Retrying after %s", dummyRetryDelay).Returns().Once()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummySession,
 		dummyClient,
 		dummyRequestObject,
 		dummyConnRetry,
 		dummyHTTPRetry,
-		dummyRetryDelay,
+		dummyRetryPolicy,
 	)
 
 	// assert
@@ -266,6 +695,10 @@ func TestClientDoWithRetry_HTTPError_RetryFail(t *testing.T) {
 	var dummyHTTPRetry = map[int]int{
 		dummyStatusCode: 2,
 	}
+	var dummySession = &session{id: uuid.New()}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyAttemptTime = time.Now()
+	var dummyElapsed = time.Duration(rand.IntN(100))
 	var dummyRetryDelay = time.Duration(rand.IntN(100))
 	var dummyResponseObject = &http.Response{
 		StatusCode: dummyStatusCode,
@@ -275,16 +708,23 @@ func TestClientDoWithRetry_HTTPError_RetryFail(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyAttemptTime).Times(4)
+	m.Mock(time.Since).Expects(dummyAttemptTime).Returns(dummyElapsed).Twice()
+	m.Mock(cloneHTTPRequest).Expects(dummyRequestObject).Returns(dummyRequestObject, nil).Times(3)
 	m.Mock((*http.Client).Do).Expects(dummyClient, dummyRequestObject).Returns(dummyResponseObject, nil).Times(3)
-	m.Mock(time.Sleep).Expects(dummyRetryDelay).Returns().Twice()
+	m.Mock((*BackoffRetryPolicy).NextDelay).Expects(dummyRetryPolicy, gomocker.Anything(), dummyElapsed, gomocker.Anything(), gomocker.Anything()).Returns(dummyRetryDelay, true).Twice()
+	m.Mock(logRetryAttempt).Expects(dummySession, gomocker.Anything(), gomocker.Anything(), gomocker.Anything(), dummyAttemptTime).Returns().Twice()
+	m.Mock(waitForRetry).Expects(gomocker.Anything(), dummyRetryDelay).Returns(nil).Twice()
+	m.Mock(logWebcallStart).Expects(dummySession, "Retry", gomocker.Anything(), "Retrying after %s", dummyRetryDelay).Returns().Twice()
 
 	// SUT + act
 	var result, err = clientDoWithRetry(
+		dummySession,
 		dummyClient,
 		dummyRequestObject,
 		dummyConnRetry,
 		dummyHTTPRetry,
-		dummyRetryDelay,
+		dummyRetryPolicy,
 	)
 
 	// assert
@@ -499,54 +939,184 @@ func TestWebREquestAddHeader_HappyPath(t *testing.T) {
 
 	// assert
 	assert.True(t, ok)
-	assert.NotNil(t, result.header)
-	assert.Equal(t, 1, len(result.header))
-	var values, found = result.header[dummyName]
-	assert.True(t, found)
-	assert.Equal(t, 3, len(values))
-	assert.Equal(t, dummyValue1, values[0])
-	assert.Equal(t, dummyValue2, values[1])
-	assert.Equal(t, dummyValue3, values[2])
+	assert.NotNil(t, result.header)
+	assert.Equal(t, 1, len(result.header))
+	var values, found = result.header[dummyName]
+	assert.True(t, found)
+	assert.Equal(t, 3, len(values))
+	assert.Equal(t, dummyValue1, values[0])
+	assert.Equal(t, dummyValue2, values[1])
+	assert.Equal(t, dummyValue3, values[2])
+}
+
+func TestWebREquestAddHeaders_HappyPath(t *testing.T) {
+	// arrange
+	var dummyName1 = "some name 1"
+	var dummyValue1 = "some value 1"
+	var dummyName2 = "some name 2"
+	var dummyValue2 = "some value 2"
+	var dummyName3 = "some name 3"
+	var dummyValue3 = "some value 3"
+	var dummyHeaders = map[string]string{
+		dummyName1: dummyValue1,
+		dummyName2: dummyValue2,
+		dummyName3: dummyValue3,
+	}
+
+	// SUT
+	var sut = &webRequest{}
+
+	// act
+	var result, ok = sut.AddHeaders(
+		dummyHeaders,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.NotNil(t, result.header)
+	assert.Equal(t, 3, len(result.header))
+	var values, found = result.header[dummyName1]
+	assert.True(t, found)
+	assert.Equal(t, 1, len(values))
+	assert.Equal(t, dummyValue1, values[0])
+	values, found = result.header[dummyName2]
+	assert.True(t, found)
+	assert.Equal(t, 1, len(values))
+	assert.Equal(t, dummyValue2, values[0])
+	values, found = result.header[dummyName3]
+	assert.True(t, found)
+	assert.Equal(t, 1, len(values))
+	assert.Equal(t, dummyValue3, values[0])
+}
+
+func TestWebRequestSetJSONBody_MarshalError(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyBody = map[string]any{"foo": func() {}}
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageWebcallPayloadInvalid, gomocker.Anything()).Returns(dummyAppError).Once()
+
+	// SUT
+	var sut = &webRequest{
+		payload: dummyPayload,
+	}
+
+	// act
+	var result, ok = sut.SetJSONBody(
+		dummyBody,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, sut, result)
+	assert.Equal(t, dummyPayload, result.payload)
+	assert.Equal(t, dummyAppError, result.payloadError)
+}
+
+func TestWebRequestSetJSONBody_Success(t *testing.T) {
+	// arrange
+	var dummyBody = map[string]any{"foo": "<bar>", "test": 123}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(setContentType).Expects(gomocker.Anything(), ContentTypeJSON).Returns().Once()
+
+	// SUT
+	var sut = &webRequest{
+		payloadStream: &streamCounter{},
+		formValues:    url.Values{"foo": {"bar"}},
+		formFiles:     []formFile{{fieldName: "some field"}},
+		payloadError:  errors.New("some error"),
+	}
+
+	// act
+	var result, ok = sut.SetJSONBody(
+		dummyBody,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, sut, result)
+	assert.Equal(t, `{"foo":"\u003cbar\u003e","test":123}`, result.payload)
+	assert.Nil(t, result.payloadStream)
+	assert.Nil(t, result.formValues)
+	assert.Nil(t, result.formFiles)
+	assert.NoError(t, result.payloadError)
+}
+
+func TestWebRequestSetFormBody(t *testing.T) {
+	// arrange
+	var dummyValues = url.Values{
+		"foo":  {"bar"},
+		"test": {"1 2", "&3"},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(setContentType).Expects(gomocker.Anything(), ContentTypeForm).Returns().Once()
+
+	// SUT
+	var sut = &webRequest{
+		payloadStream: &streamCounter{},
+		payloadError:  errors.New("some error"),
+	}
+
+	// act
+	var result, ok = sut.SetFormBody(
+		dummyValues,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, sut, result)
+	assert.Equal(t, "foo=bar&test=1+2&test=%263", result.payload)
+	assert.Nil(t, result.payloadStream)
+	assert.Equal(t, dummyValues, result.formValues)
+	assert.NoError(t, result.payloadError)
 }
 
-func TestWebREquestAddHeaders_HappyPath(t *testing.T) {
+func TestWebRequestAddFormFile(t *testing.T) {
 	// arrange
-	var dummyName1 = "some name 1"
-	var dummyValue1 = "some value 1"
-	var dummyName2 = "some name 2"
-	var dummyValue2 = "some value 2"
-	var dummyName3 = "some name 3"
-	var dummyValue3 = "some value 3"
-	var dummyHeaders = map[string]string{
-		dummyName1: dummyValue1,
-		dummyName2: dummyValue2,
-		dummyName3: dummyValue3,
-	}
+	var dummyFieldName1 = "some field name 1"
+	var dummyFileName1 = "some file name 1"
+	var dummyContent1 = strings.NewReader("some content 1")
+	var dummyFieldName2 = "some field name 2"
+	var dummyFileName2 = "some file name 2"
+	var dummyContent2 = strings.NewReader("some content 2")
 
 	// SUT
-	var sut = &webRequest{}
+	var sut = &webRequest{
+		payloadStream: &streamCounter{},
+	}
 
 	// act
-	var result, ok = sut.AddHeaders(
-		dummyHeaders,
+	var result, ok = sut.AddFormFile(
+		dummyFieldName1,
+		dummyFileName1,
+		dummyContent1,
+	).AddFormFile(
+		dummyFieldName2,
+		dummyFileName2,
+		dummyContent2,
 	).(*webRequest)
 
 	// assert
 	assert.True(t, ok)
-	assert.NotNil(t, result.header)
-	assert.Equal(t, 3, len(result.header))
-	var values, found = result.header[dummyName1]
-	assert.True(t, found)
-	assert.Equal(t, 1, len(values))
-	assert.Equal(t, dummyValue1, values[0])
-	values, found = result.header[dummyName2]
-	assert.True(t, found)
-	assert.Equal(t, 1, len(values))
-	assert.Equal(t, dummyValue2, values[0])
-	values, found = result.header[dummyName3]
-	assert.True(t, found)
-	assert.Equal(t, 1, len(values))
-	assert.Equal(t, dummyValue3, values[0])
+	assert.Equal(t, sut, result)
+	assert.Nil(t, result.payloadStream)
+	assert.Equal(t, []formFile{
+		{dummyFieldName1, dummyFileName1, dummyContent1},
+		{dummyFieldName2, dummyFileName2, dummyContent2},
+	}, result.formFiles)
 }
 
 func TestWebRequestSetupRetry(t *testing.T) {
@@ -575,6 +1145,60 @@ func TestWebRequestSetupRetry(t *testing.T) {
 	assert.Equal(t, dummyRetryDelay, result.retryDelay)
 }
 
+func TestWebRequestSetupRetryPolicy(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &BackoffRetryPolicy{
+		InitialDelay: time.Duration(rand.IntN(100)),
+	}
+
+	// SUT
+	var sut = &webRequest{}
+
+	// act
+	var result, ok = sut.SetupRetryPolicy(
+		dummyRetryPolicy,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyRetryPolicy, result.retryPolicy)
+}
+
+func TestWebRequestWithContext(t *testing.T) {
+	// arrange
+	type contextKey struct{}
+	var dummyContext = context.WithValue(context.Background(), contextKey{}, rand.Int())
+
+	// SUT
+	var sut = &webRequest{}
+
+	// act
+	var result, ok = sut.WithContext(
+		dummyContext,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyContext, result.ctx)
+}
+
+func TestWebRequestWithTimeout(t *testing.T) {
+	// arrange
+	var dummyTimeout = time.Duration(rand.IntN(100))
+
+	// SUT
+	var sut = &webRequest{}
+
+	// act
+	var result, ok = sut.WithTimeout(
+		dummyTimeout,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, dummyTimeout, result.timeout)
+}
+
 func TestWebRequestAnticipate_NoStatusCodes(t *testing.T) {
 	// arrange
 	var dummyDataTemplate1 string
@@ -652,6 +1276,57 @@ func TestWebRequestAnticipate_WithStatusCodes(t *testing.T) {
 	assert.Equal(t, 999, result.dataReceivers[3].codeRange.End)
 }
 
+func TestWebRequestAnticipateStream(t *testing.T) {
+	// arrange
+	var dummyStatusCode = rand.IntN(100) + 100
+	var dummyError = errors.New("some error")
+	var dummyHandler = func(body io.Reader) error {
+		return dummyError
+	}
+
+	// SUT
+	var sut = &webRequest{}
+
+	// act
+	var result, ok = sut.AnticipateStream(
+		dummyHandler,
+		dummyStatusCode,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, sut, result)
+	assert.Equal(t, 1, len(result.dataReceivers))
+	var receiver, isStream = result.dataReceivers[0].dataTemplate.(*streamReceiver)
+	assert.True(t, isStream)
+	assert.Equal(t, dummyError, receiver.handler(nil))
+	assert.Equal(t, StatusCodeRange{dummyStatusCode, dummyStatusCode + 1}, result.dataReceivers[0].codeRange)
+}
+
+func TestWebRequestAnticipateWriter(t *testing.T) {
+	// arrange
+	var dummyWriter = &bytes.Buffer{}
+	var dummyContent = "some content"
+
+	// SUT
+	var sut = &webRequest{}
+
+	// act
+	var result, ok = sut.AnticipateWriter(
+		dummyWriter,
+	).(*webRequest)
+
+	// assert
+	assert.True(t, ok)
+	assert.Equal(t, sut, result)
+	assert.Equal(t, 1, len(result.dataReceivers))
+	var receiver, isStream = result.dataReceivers[0].dataTemplate.(*streamReceiver)
+	assert.True(t, isStream)
+	assert.NoError(t, receiver.handler(strings.NewReader(dummyContent)))
+	assert.Equal(t, dummyContent, dummyWriter.String())
+	assert.Equal(t, StatusCodeRange{0, 999}, result.dataReceivers[0].codeRange)
+}
+
 func TestCreateQueryString_NilQuery(t *testing.T) {
 	// arrange
 	var dummyQuery map[string][]string
@@ -820,8 +1495,131 @@ func TestGenerateRequestURL_Success(t *testing.T) {
 	assert.Equal(t, dummyResult, result)
 }
 
+func TestGetRequestContext_NoContext_NoTimeout(t *testing.T) {
+	// arrange
+	type contextKey struct{}
+	var dummyValue = rand.Int()
+	var dummySessionContext = context.WithValue(context.Background(), contextKey{}, dummyValue)
+	var dummySession = &session{
+		request: (&http.Request{}).WithContext(dummySessionContext),
+	}
+	var dummyWebRequest = &webRequest{
+		session: dummySession,
+	}
+
+	// SUT + act
+	var result, cancel = getRequestContext(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyValue, result.Value(contextKey{}))
+	var _, hasDeadline = result.Deadline()
+	assert.False(t, hasDeadline)
+	assert.NoError(t, result.Err())
+	cancel()
+	assert.Equal(t, context.Canceled, result.Err())
+}
+
+func TestGetRequestContext_WithContext_WithTimeout(t *testing.T) {
+	// arrange
+	type contextKey struct{}
+	var dummyValue = rand.Int()
+	var dummyContext = context.WithValue(context.Background(), contextKey{}, dummyValue)
+	var dummyTimeout = time.Duration(rand.IntN(100)+100) * time.Second
+	var dummyWebRequest = &webRequest{
+		session: &session{},
+		ctx:     dummyContext,
+		timeout: dummyTimeout,
+	}
+
+	// SUT + act
+	var result, cancel = getRequestContext(
+		dummyWebRequest,
+	)
+	defer cancel()
+
+	// assert
+	assert.Equal(t, dummyValue, result.Value(contextKey{}))
+	var deadline, hasDeadline = result.Deadline()
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(dummyTimeout), deadline, time.Second)
+}
+
+func TestGetRequestContext_ForceCancelled(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		session: &session{},
+		ctx:     context.Background(),
+	}
+
+	// SUT + act
+	var result, cancel = getRequestContext(
+		dummyWebRequest,
+	)
+	defer cancel()
+	forceCancelWebcalls()
+
+	// assert
+	<-result.Done()
+	assert.Equal(t, context.Canceled, result.Err())
+}
+
+func TestGetRequestContext_AfterForceCancelled(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		session: &session{},
+		ctx:     context.Background(),
+	}
+	forceCancelWebcalls()
+
+	// SUT + act
+	var result, cancel = getRequestContext(
+		dummyWebRequest,
+	)
+	defer cancel()
+
+	// assert
+	assert.NoError(t, result.Err())
+}
+
+func TestWebRequestProcess_AfterForceCancelled(t *testing.T) {
+	// arrange
+	var dummyServer = httptest.NewServer(http.HandlerFunc(
+		func(responseWriter http.ResponseWriter, httpRequest *http.Request) {
+			responseWriter.WriteHeader(http.StatusOK)
+		},
+	))
+	defer dummyServer.Close()
+	httpClientCustom = dummyServer.Client()
+	defer func() { httpClientCustom = nil }()
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: &DefaultCustomization{},
+	}
+	forceCancelWebcalls()
+
+	// SUT
+	var sut = dummySession.CreateWebcallRequest(
+		http.MethodGet,
+		dummyServer.URL,
+		"",
+		false,
+	).WithContext(
+		context.Background(),
+	)
+
+	// act
+	var statusCode, _, err = sut.Process()
+
+	// assert
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.NoError(t, err)
+}
+
 func TestCreateHTTPRequest_NilWebRequest(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyWebRequest *webRequest
 	var dummyAppError = &appError{Message: "some error message"}
 
@@ -834,6 +1632,7 @@ func TestCreateHTTPRequest_NilWebRequest(t *testing.T) {
 	// SUT + act
 	var result, err = createHTTPRequest(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -843,6 +1642,7 @@ func TestCreateHTTPRequest_NilWebRequest(t *testing.T) {
 
 func TestCreateHTTPRequest_NilWebRequestSession(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyWebRequest = &webRequest{}
 	var dummyAppError = &appError{Message: "some error message"}
 
@@ -855,6 +1655,7 @@ func TestCreateHTTPRequest_NilWebRequestSession(t *testing.T) {
 	// SUT + act
 	var result, err = createHTTPRequest(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -862,8 +1663,34 @@ func TestCreateHTTPRequest_NilWebRequestSession(t *testing.T) {
 	assert.Equal(t, dummyAppError, err)
 }
 
+func TestCreateHTTPRequest_PayloadError(t *testing.T) {
+	// arrange
+	var dummyContext = context.TODO()
+	var dummyWebRequest = &webRequest{
+		session: &session{},
+	}
+	var dummyError = errors.New("some error message")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(prepareWebcallPayload).Expects(dummyWebRequest).Returns(dummyError).Once()
+
+	// SUT + act
+	var result, err = createHTTPRequest(
+		dummyWebRequest,
+		dummyContext,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyError, err)
+}
+
 func TestCreateHTTPRequest_RequestError(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummySession = &session{}
 	var dummyMethod = "some method"
 	var dummyURL = "some URL"
@@ -898,25 +1725,36 @@ func TestCreateHTTPRequest_RequestError(t *testing.T) {
 		dummySendClientCert,
 		dummyRetryDelay,
 		dummyDataReceivers,
+		nil,
+		0,
+		nil,
+		nil,
+		false,
+		nil,
+		nil,
+		nil,
+		false,
 	}
 	var dummyRequestURL = "some request url"
 	var dummyRequest *http.Request
 	var dummyError = errors.New("some error message")
 
 	// stub
-	var dummyStingsReader = strings.NewReader(dummyPayload)
+	var dummyRequestBody = strings.NewReader(dummyPayload)
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(prepareWebcallPayload).Expects(dummyWebRequest).Returns(nil).Once()
 	m.Mock(generateRequestURL).Expects(dummyURL, dummyQuery).Returns(dummyRequestURL).Once()
-	m.Mock(strings.NewReader).Expects(dummyPayload).Returns(dummyStingsReader).Once()
-	m.Mock(http.NewRequest).Expects(dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, dummyError).Once()
+	m.Mock(getWebcallRequestBody).Expects(dummyWebRequest).Returns(dummyRequestBody).Once()
+	m.Mock(http.NewRequestWithContext).Expects(dummyContext, dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, dummyError).Once()
 
 	// SUT + act
 	var result, err = createHTTPRequest(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -926,6 +1764,7 @@ func TestCreateHTTPRequest_RequestError(t *testing.T) {
 
 func TestCreateHTTPRequest_Success(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyCustomization = &DefaultCustomization{}
 	var dummySession = &session{
 		customization: dummyCustomization,
@@ -963,6 +1802,15 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 		dummySendClientCert,
 		dummyRetryDelay,
 		dummyDataReceivers,
+		nil,
+		0,
+		nil,
+		nil,
+		false,
+		nil,
+		nil,
+		nil,
+		false,
 	}
 	var dummyRequestURL = "some request url"
 	var dummyRequest = &http.Request{
@@ -974,17 +1822,18 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 	}
 
 	// stub
-	var dummyStingsReader = strings.NewReader(dummyPayload)
+	var dummyRequestBody = strings.NewReader(dummyPayload)
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
+	m.Mock(prepareWebcallPayload).Expects(dummyWebRequest).Returns(nil).Once()
 	m.Mock(generateRequestURL).Expects(dummyURL, dummyQuery).Returns(dummyRequestURL).Once()
-	m.Mock(strings.NewReader).Expects(dummyPayload).Returns(dummyStingsReader).Once()
-	m.Mock(http.NewRequest).Expects(dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, nil).Once()
+	m.Mock(getWebcallRequestBody).Expects(dummyWebRequest).Returns(dummyRequestBody).Once()
+	m.Mock(http.NewRequestWithContext).Expects(dummyContext, dummyMethod, dummyRequestURL, gomocker.Anything()).Returns(dummyRequest, nil).Once()
 	m.Mock(logWebcallStart).Expects(dummySession, dummyMethod, dummyURL, "%s", dummyRequestURL).Returns().Once()
-	m.Mock(logWebcallRequest).Expects(dummySession, "Payload", "Content", "%s", dummyPayload).Returns().Once()
+	m.Mock(logRequestPayload).Expects(dummyWebRequest).Returns().Once()
 	m.Mock(logWebcallRequest).Expects(dummySession, "Header", "Content", "%s", dummyHeaderContent).Returns().Once()
 	m.Mock(marshalIgnoreError).Expects(gomocker.Anything()).Returns(dummyHeaderContent).Once()
 	m.Mock((*DefaultCustomization).WrapRequest).Expects(dummyCustomization, dummySession, dummyRequest).Returns(dummyCustomized).Once()
@@ -992,6 +1841,7 @@ func TestCreateHTTPRequest_Success(t *testing.T) {
 	// SUT + act
 	var result, err = createHTTPRequest(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -1063,30 +1913,118 @@ func TestLogSuccessResponse_ValidResponse(t *testing.T) {
 	// mock
 	var m = gomocker.NewMocker(t)
 
-	// expect
-	m.Mock(io.ReadAll).Expects(dummyBody).Returns(dummyResponseBytes, dummyError).Once()
-	m.Mock(bytes.NewBuffer).Expects(dummyResponseBytes).Returns(dummyBuffer).Once()
-	m.Mock(io.NopCloser).Expects(dummyBuffer).Returns(dummyNewBody).Once()
-	m.Mock(http.StatusText).Expects(dummyStatusCode).Returns(dummyStatus).Once()
-	m.Mock(logWebcallResponse).Expects(dummySession, "Header", "Content", "%s", dummyHeaderContent).Returns().Once()
-	m.Mock(logWebcallResponse).Expects(dummySession, "Body", "Content", "%s", dummyResponseBody).Returns().Once()
-	m.Mock(marshalIgnoreError).Expects(gomocker.Anything()).Returns(dummyHeaderContent).Once()
-	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyTimeSince).Once()
-	m.Mock(logWebcallFinish).Expects(dummySession, dummyStatus, strconv.Itoa(dummyStatusCode), "%s", dummyTimeSince).Returns().Once()
+	// expect
+	m.Mock(io.ReadAll).Expects(dummyBody).Returns(dummyResponseBytes, dummyError).Once()
+	m.Mock(bytes.NewBuffer).Expects(dummyResponseBytes).Returns(dummyBuffer).Once()
+	m.Mock(io.NopCloser).Expects(dummyBuffer).Returns(dummyNewBody).Once()
+	m.Mock(http.StatusText).Expects(dummyStatusCode).Returns(dummyStatus).Once()
+	m.Mock(logWebcallResponse).Expects(dummySession, "Header", "Content", "%s", dummyHeaderContent).Returns().Once()
+	m.Mock(logWebcallResponse).Expects(dummySession, "Body", "Content", "%s", dummyResponseBody).Returns().Once()
+	m.Mock(marshalIgnoreError).Expects(gomocker.Anything()).Returns(dummyHeaderContent).Once()
+	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyTimeSince).Once()
+	m.Mock(logWebcallFinish).Expects(dummySession, dummyStatus, strconv.Itoa(dummyStatusCode), "%s", dummyTimeSince).Returns().Once()
+
+	// SUT + act
+	logSuccessResponse(
+		dummySession,
+		dummyResponse,
+		dummyStartTime,
+	)
+
+	// assert
+	assert.Equal(t, dummyNewBody, dummyResponse.Body)
+}
+
+func TestGetCancellationError_DeadlineExceeded(t *testing.T) {
+	// arrange
+	var dummyResponseError = fmt.Errorf("some error: %w", context.DeadlineExceeded)
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(errorCodeRequestTimeout, errorMessageWebcallTimeout, dummyResponseError).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = getCancellationError(
+		dummyResponseError,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestGetCancellationError_Canceled(t *testing.T) {
+	// arrange
+	var dummyResponseError = fmt.Errorf("some error: %w", context.Canceled)
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(newAppError).Expects(errorCodeRequestCancelled, errorMessageWebcallCancelled, dummyResponseError).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = getCancellationError(
+		dummyResponseError,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestGetCancellationError_OtherError(t *testing.T) {
+	// arrange
+	var dummyResponseError = errors.New("some error")
+
+	// SUT + act
+	var err = getCancellationError(
+		dummyResponseError,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseError, err)
+}
+
+func TestGetRetryPolicy_Custom(t *testing.T) {
+	// arrange
+	var dummyRetryPolicy = &BackoffRetryPolicy{
+		InitialDelay: time.Duration(rand.IntN(100)),
+	}
+	var dummyWebRequest = &webRequest{
+		retryPolicy: dummyRetryPolicy,
+	}
+
+	// SUT + act
+	var result = getRetryPolicy(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyRetryPolicy, result)
+}
+
+func TestGetRetryPolicy_Default(t *testing.T) {
+	// arrange
+	var dummyRetryDelay = time.Duration(rand.IntN(100))
+	var dummyWebRequest = &webRequest{
+		retryDelay: dummyRetryDelay,
+	}
 
 	// SUT + act
-	logSuccessResponse(
-		dummySession,
-		dummyResponse,
-		dummyStartTime,
+	var result = getRetryPolicy(
+		dummyWebRequest,
 	)
 
 	// assert
-	assert.Equal(t, dummyNewBody, dummyResponse.Body)
+	assert.Equal(t, &BackoffRetryPolicy{InitialDelay: dummyRetryDelay}, result)
 }
 
 func TestDoRequestProcessing_NilWebRequest(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyWebRequest *webRequest
 	var dummyAppError = &appError{Message: "some error message"}
 
@@ -1099,6 +2037,7 @@ func TestDoRequestProcessing_NilWebRequest(t *testing.T) {
 	// SUT + act
 	var result, err = doRequestProcessing(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -1108,6 +2047,7 @@ func TestDoRequestProcessing_NilWebRequest(t *testing.T) {
 
 func TestDoRequestProcessing_NilWebRequestSession(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyWebRequest = &webRequest{}
 	var dummyAppError = &appError{Message: "some error message"}
 
@@ -1120,6 +2060,7 @@ func TestDoRequestProcessing_NilWebRequestSession(t *testing.T) {
 	// SUT + act
 	var result, err = doRequestProcessing(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -1129,6 +2070,7 @@ func TestDoRequestProcessing_NilWebRequestSession(t *testing.T) {
 
 func TestDoRequestProcessing_RequestError(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyWebRequest = &webRequest{
 		session: &session{id: uuid.New()},
 	}
@@ -1139,11 +2081,12 @@ func TestDoRequestProcessing_RequestError(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(createHTTPRequest).Expects(dummyWebRequest).Returns(dummyRequestObject, dummyRequestError).Once()
+	m.Mock(createHTTPRequest).Expects(dummyWebRequest, dummyContext).Returns(dummyRequestObject, dummyRequestError).Once()
 
 	// SUT + act
 	var result, err = doRequestProcessing(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -1151,8 +2094,46 @@ func TestDoRequestProcessing_RequestError(t *testing.T) {
 	assert.Equal(t, dummyRequestError, err)
 }
 
+func TestDoRequestProcessing_CircuitOpen(t *testing.T) {
+	// arrange
+	var dummyContext = context.TODO()
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: dummyCustomization,
+	}
+	var dummyWebRequest = &webRequest{
+		session: dummySession,
+	}
+	var dummyRequestObject = &http.Request{}
+	var dummyCircuitSetting = &CircuitBreakerSetting{Key: "some key"}
+	var dummyCircuitError = errors.New("some circuit error")
+	var dummyStartTime = time.Now()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(createHTTPRequest).Expects(dummyWebRequest, dummyContext).Returns(dummyRequestObject, nil).Once()
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock((*DefaultCustomization).CircuitBreaker).Expects(dummyCustomization, dummySession, dummyRequestObject).Returns(dummyCircuitSetting).Once()
+	m.Mock(allowCircuitRequest).Expects(dummySession, dummyCircuitSetting, dummyRequestObject).Returns(dummyCircuitError).Once()
+	m.Mock(logErrorResponse).Expects(dummySession, dummyCircuitError, dummyStartTime).Returns().Once()
+
+	// SUT + act
+	var result, err = doRequestProcessing(
+		dummyWebRequest,
+		dummyContext,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, dummyCircuitError, err)
+}
+
 func TestDoRequestProcessing_ResponseError(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyCustomization = &DefaultCustomization{}
 	var dummySession = &session{
 		id:            uuid.New(),
@@ -1173,34 +2154,45 @@ func TestDoRequestProcessing_ResponseError(t *testing.T) {
 		retryDelay:     dummyRetryDelay,
 	}
 	var dummyHTTPClient = &http.Client{}
+	var dummyCircuitSetting = &CircuitBreakerSetting{Key: "some key"}
+	var dummyRetryPolicy = &BackoffRetryPolicy{InitialDelay: dummyRetryDelay}
 	var dummyRequestObject = &http.Request{}
 	var dummyResponseObject *http.Response
 	var dummyResponseError = errors.New("some error")
+	var dummyCancellationError = errors.New("some cancellation error")
 	var dummyStartTime = time.Now()
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(createHTTPRequest).Expects(dummyWebRequest).Returns(dummyRequestObject, nil).Once()
-	m.Mock(getClientForRequest).Expects(dummySendClientCert).Returns(dummyHTTPClient).Once()
+	m.Mock(createHTTPRequest).Expects(dummyWebRequest, dummyContext).Returns(dummyRequestObject, nil).Once()
 	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
-	m.Mock(clientDoWithRetry).Expects(dummyHTTPClient, dummyRequestObject, dummyConnRetry, dummyHTTPRetry, dummyRetryDelay).Returns(dummyResponseObject, dummyResponseError).Once()
-	m.Mock(logErrorResponse).Expects(dummySession, dummyResponseError, dummyStartTime).Returns().Once()
-	m.Mock((*DefaultCustomization).WrapResponse).Expects(dummyCustomization, dummySession, dummyResponseObject, dummyResponseError).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock((*DefaultCustomization).CircuitBreaker).Expects(dummyCustomization, dummySession, dummyRequestObject).Returns(dummyCircuitSetting).Once()
+	m.Mock(allowCircuitRequest).Expects(dummySession, dummyCircuitSetting, dummyRequestObject).Returns(nil).Once()
+	m.Mock(getClientForRequest).Expects(dummySendClientCert).Returns(dummyHTTPClient).Once()
+	m.Mock(getRetryPolicy).Expects(dummyWebRequest).Returns(dummyRetryPolicy).Once()
+	m.Mock(clientDoWithRetry).Expects(dummySession, dummyHTTPClient, dummyRequestObject, dummyConnRetry, dummyHTTPRetry, dummyRetryPolicy).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(logStreamedPayload).Expects(dummyWebRequest).Returns().Once()
+	m.Mock(recordCircuitResult).Expects(dummySession, dummyCircuitSetting, dummyRequestObject, dummyResponseObject, dummyResponseError).Returns().Once()
+	m.Mock(getCancellationError).Expects(dummyResponseError).Returns(dummyCancellationError).Once()
+	m.Mock(logErrorResponse).Expects(dummySession, dummyCancellationError, dummyStartTime).Returns().Once()
+	m.Mock((*DefaultCustomization).WrapResponse).Expects(dummyCustomization, dummySession, dummyResponseObject, dummyCancellationError).Returns(dummyResponseObject, dummyCancellationError).Once()
 
 	// SUT + act
 	var result, err = doRequestProcessing(
 		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
 	assert.Equal(t, dummyResponseObject, result)
-	assert.Equal(t, dummyResponseError, err)
+	assert.Equal(t, dummyCancellationError, err)
 }
 
 func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyCustomization = &DefaultCustomization{}
 	var dummySession = &session{
 		id:            uuid.New(),
@@ -1221,6 +2213,8 @@ func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 		retryDelay:     dummyRetryDelay,
 	}
 	var dummyHTTPClient = &http.Client{}
+	var dummyCircuitSetting = &CircuitBreakerSetting{Key: "some key"}
+	var dummyRetryPolicy = &BackoffRetryPolicy{InitialDelay: dummyRetryDelay}
 	var dummyRequestObject = &http.Request{}
 	var dummyResponseObject = &http.Response{}
 	var dummyStartTime = time.Now()
@@ -1229,16 +2223,78 @@ func TestDoRequestProcessing_ResponseSuccess(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(createHTTPRequest).Expects(dummyWebRequest).Returns(dummyRequestObject, nil).Once()
-	m.Mock(getClientForRequest).Expects(dummySendClientCert).Returns(dummyHTTPClient).Once()
+	m.Mock(createHTTPRequest).Expects(dummyWebRequest, dummyContext).Returns(dummyRequestObject, nil).Once()
 	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
-	m.Mock(clientDoWithRetry).Expects(dummyHTTPClient, dummyRequestObject, dummyConnRetry, dummyHTTPRetry, dummyRetryDelay).Returns(dummyResponseObject, nil).Once()
+	m.Mock((*DefaultCustomization).CircuitBreaker).Expects(dummyCustomization, dummySession, dummyRequestObject).Returns(dummyCircuitSetting).Once()
+	m.Mock(allowCircuitRequest).Expects(dummySession, dummyCircuitSetting, dummyRequestObject).Returns(nil).Once()
+	m.Mock(getClientForRequest).Expects(dummySendClientCert).Returns(dummyHTTPClient).Once()
+	m.Mock(getRetryPolicy).Expects(dummyWebRequest).Returns(dummyRetryPolicy).Once()
+	m.Mock(clientDoWithRetry).Expects(dummySession, dummyHTTPClient, dummyRequestObject, dummyConnRetry, dummyHTTPRetry, dummyRetryPolicy).Returns(dummyResponseObject, nil).SideEffects(
+		gomocker.ParamSideEffect(1, 5, func(value map[int]int) { clear(value) })).Once()
+	m.Mock(logStreamedPayload).Expects(dummyWebRequest).Returns().Once()
+	m.Mock(recordCircuitResult).Expects(dummySession, dummyCircuitSetting, dummyRequestObject, dummyResponseObject, nil).Returns().Once()
+	m.Mock(isStreamResponse).Expects(dummyResponseObject, gomocker.Anything()).Returns(false).Once()
 	m.Mock(logSuccessResponse).Expects(dummySession, dummyResponseObject, dummyStartTime).Returns().Once()
 	m.Mock((*DefaultCustomization).WrapResponse).Expects(dummyCustomization, dummySession, dummyResponseObject, nil).Returns(dummyResponseObject, nil).Once()
 
 	// SUT + act
 	var result, err = doRequestProcessing(
 		dummyWebRequest,
+		dummyContext,
+	)
+
+	// assert
+	assert.Equal(t, dummyResponseObject, result)
+	assert.NotEmpty(t, dummyHTTPRetry)
+	assert.NoError(t, err)
+}
+
+func TestDoRequestProcessing_ResponseStream(t *testing.T) {
+	// arrange
+	var dummyContext = context.TODO()
+	var dummyCustomization = &DefaultCustomization{}
+	var dummySession = &session{
+		id:            uuid.New(),
+		customization: dummyCustomization,
+	}
+	var dummyConnRetry = rand.Int()
+	var dummySendClientCert = rand.IntN(100) < 50
+	var dummyDataReceivers = []dataReceiver{
+		{&streamReceiver{}, StatusCodeRange{0, 999}},
+	}
+	var dummyWebRequest = &webRequest{
+		session:        dummySession,
+		connRetry:      dummyConnRetry,
+		sendClientCert: dummySendClientCert,
+		dataReceivers:  dummyDataReceivers,
+	}
+	var dummyHTTPClient = &http.Client{}
+	var dummyRetryPolicy = &BackoffRetryPolicy{}
+	var dummyRequestObject = &http.Request{}
+	var dummyResponseObject = &http.Response{}
+	var dummyStartTime = time.Now()
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(createHTTPRequest).Expects(dummyWebRequest, dummyContext).Returns(dummyRequestObject, nil).Once()
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock((*DefaultCustomization).CircuitBreaker).Expects(dummyCustomization, dummySession, dummyRequestObject).Returns(nil).Once()
+	m.Mock(allowCircuitRequest).Expects(dummySession, gomocker.Anything(), dummyRequestObject).Returns(nil).Once()
+	m.Mock(getClientForRequest).Expects(dummySendClientCert).Returns(dummyHTTPClient).Once()
+	m.Mock(getRetryPolicy).Expects(dummyWebRequest).Returns(dummyRetryPolicy).Once()
+	m.Mock(clientDoWithRetry).Expects(dummySession, dummyHTTPClient, dummyRequestObject, dummyConnRetry, gomocker.Anything(), dummyRetryPolicy).Returns(dummyResponseObject, nil).Once()
+	m.Mock(logStreamedPayload).Expects(dummyWebRequest).Returns().Once()
+	m.Mock(recordCircuitResult).Expects(dummySession, gomocker.Anything(), dummyRequestObject, dummyResponseObject, nil).Returns().Once()
+	m.Mock(isStreamResponse).Expects(dummyResponseObject, dummyDataReceivers).Returns(true).Once()
+	m.Mock(logStreamResponse).Expects(dummySession, dummyResponseObject, dummyStartTime).Returns().Once()
+	m.Mock((*DefaultCustomization).WrapResponse).Expects(dummyCustomization, dummySession, dummyResponseObject, nil).Returns(dummyResponseObject, nil).Once()
+
+	// SUT + act
+	var result, err = doRequestProcessing(
+		dummyWebRequest,
+		dummyContext,
 	)
 
 	// assert
@@ -1408,6 +2464,31 @@ func TestParseResponse_NilDataTemplate(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestParseResponse_Stream(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyBody = io.NopCloser(bytes.NewBufferString("some body"))
+	var dummyDataTemplate = &streamReceiver{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(isInterfaceValueNil).Expects(dummyDataTemplate).Returns(false).Once()
+	m.Mock(consumeResponseStream).Expects(dummySession, dummyBody, dummyDataTemplate).Returns(dummyError).Once()
+
+	// SUT + act
+	var err = parseResponse(
+		dummySession,
+		dummyBody,
+		dummyDataTemplate,
+	)
+
+	// assert
+	assert.Equal(t, dummyError, err)
+}
+
 func TestParseResponse_ReadError(t *testing.T) {
 	// arrange
 	var dummySession = &session{id: uuid.New()}
@@ -1539,10 +2620,18 @@ func TestWebRequestProcess_NilWebRequestSession(t *testing.T) {
 	assert.Equal(t, dummyAppError, err)
 }
 
-func TestWebRequestProcess_Error_NilObject(t *testing.T) {
+func TestWebRequestProcess_HappyPath(t *testing.T) {
 	// arrange
-	var dummyResponseObject *http.Response
-	var dummyResponseError = errors.New("some error")
+	var dummyContext = context.TODO()
+	type dummyContextKey struct{}
+	var dummyCancel = func() {}
+	var dummySpanContext = context.WithValue(context.TODO(), dummyContextKey{}, "some value")
+	var dummySpan = tracenoop.Span{}
+	var dummyStartTime = time.Now()
+	var dummyDuration = time.Duration(rand.IntN(100))
+	var dummyStatusCode = rand.Int()
+	var dummyHeader = http.Header{"foo": {"bar"}}
+	var dummyError = errors.New("some error")
 
 	// SUT
 	var sut = &webRequest{
@@ -1553,27 +2642,78 @@ func TestWebRequestProcess_Error_NilObject(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(getRequestContext).Expects(sut).Returns(dummyContext, dummyCancel).Once()
+	m.Mock(startClientSpan).Expects(sut, dummyContext).Returns(dummySpanContext, dummySpan).Once()
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock(processWebRequest).Expects(sut, dummySpanContext).Returns(dummyStatusCode, dummyHeader, dummyError).Once()
+	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyDuration).Once()
+	m.Mock(finishClientTelemetry).Expects(sut, dummySpanContext, dummySpan, dummyDuration, dummyStatusCode, dummyError).Returns().Once()
+	m.Mock(recordWebcallMetrics).Expects(sut, dummyDuration, dummyStatusCode).Returns().Once()
+	m.Mock(dummyCancel).Expects().Returns().Once()
 
 	// act
 	var result, header, err = sut.Process()
 
+	// assert
+	assert.Equal(t, dummyStatusCode, result)
+	assert.Equal(t, dummyHeader, header)
+	assert.Equal(t, dummyError, err)
+}
+
+type dummyClosingReadCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (readCloser *dummyClosingReadCloser) Close() error {
+	readCloser.closed = true
+	return nil
+}
+
+func TestProcessWebRequest_Error_NilObject(t *testing.T) {
+	// arrange
+	var dummyContext = context.TODO()
+	var dummyResponseObject *http.Response
+	var dummyResponseError = errors.New("some error")
+
+	// SUT
+	var sut = &webRequest{
+		session:          &session{id: uuid.New()},
+		responseReceived: true,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(doRequestProcessing).Expects(sut, dummyContext).Returns(dummyResponseObject, dummyResponseError).Once()
+
+	// act
+	var result, header, err = processWebRequest(
+		sut,
+		dummyContext,
+	)
+
 	// assert
 	assert.Equal(t, http.StatusInternalServerError, result)
 	assert.Empty(t, header)
 	assert.Equal(t, dummyResponseError, err)
+	assert.False(t, sut.responseReceived)
 }
 
-func TestWebRequestProcess_Error_ValidObject(t *testing.T) {
+func TestProcessWebRequest_Error_ValidObject(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyStatusCode = rand.Int()
 	var dummyHeader = map[string][]string{
 		"foo":  {"bar"},
 		"test": {"123", "456", "789"},
 	}
+	var dummyBody = &dummyClosingReadCloser{Reader: bytes.NewBufferString("some body")}
 	var dummyResponseObject = &http.Response{
 		StatusCode: dummyStatusCode,
 		Header:     dummyHeader,
+		Body:       dummyBody,
 	}
 	var dummyResponseError = errors.New("some error")
 
@@ -1586,45 +2726,91 @@ func TestWebRequestProcess_Error_ValidObject(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(doRequestProcessing).Expects(sut, dummyContext).Returns(dummyResponseObject, dummyResponseError).Once()
 
 	// act
-	var result, header, err = sut.Process()
+	var result, header, err = processWebRequest(
+		sut,
+		dummyContext,
+	)
 
 	// assert
 	assert.Equal(t, dummyStatusCode, result)
 	assert.Equal(t, http.Header(dummyHeader), header)
 	assert.Equal(t, dummyResponseError, err)
+	assert.True(t, sut.responseReceived)
+	assert.True(t, dummyBody.closed)
+}
+
+func TestProcessWebRequest_Error_ValidObjectNilBody(t *testing.T) {
+	// arrange
+	var dummyContext = context.TODO()
+	var dummyStatusCode = rand.Int()
+	var dummyResponseObject = &http.Response{
+		StatusCode: dummyStatusCode,
+		Header:     http.Header{},
+	}
+	var dummyResponseError = errors.New("some error")
+
+	// SUT
+	var sut = &webRequest{
+		session: &session{id: uuid.New()},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(doRequestProcessing).Expects(sut, dummyContext).Returns(dummyResponseObject, dummyResponseError).Once()
+
+	// act
+	var result, header, err = processWebRequest(
+		sut,
+		dummyContext,
+	)
+
+	// assert
+	assert.Equal(t, dummyStatusCode, result)
+	assert.Empty(t, header)
+	assert.Equal(t, dummyResponseError, err)
+	assert.True(t, sut.responseReceived)
 }
 
-func TestWebRequestProcess_Success_NilObject(t *testing.T) {
+func TestProcessWebRequest_Success_NilObject(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyResponseObject *http.Response
 	var dummyResponseError error
 
 	// SUT
 	var sut = &webRequest{
-		session: &session{id: uuid.New()},
+		session:          &session{id: uuid.New()},
+		responseReceived: true,
 	}
 
 	// mock
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(doRequestProcessing).Expects(sut, dummyContext).Returns(dummyResponseObject, dummyResponseError).Once()
 	m.Mock(logWebcallResponse).Expects(sut.session, "webRequest", "Process", "Nil response object received").Returns().Once()
 
 	// act
-	var result, header, err = sut.Process()
+	var result, header, err = processWebRequest(
+		sut,
+		dummyContext,
+	)
 
 	// assert
 	assert.Zero(t, result)
 	assert.Empty(t, header)
 	assert.NoError(t, err)
+	assert.False(t, sut.responseReceived)
 }
 
-func TestWebRequestProcess_Success_ValidObject(t *testing.T) {
+func TestProcessWebRequest_Success_ValidObject(t *testing.T) {
 	// arrange
+	var dummyContext = context.TODO()
 	var dummyStatusCode = rand.Int()
 	var dummyHeader = map[string][]string{
 		"foo":  {"bar"},
@@ -1655,17 +2841,174 @@ func TestWebRequestProcess_Success_ValidObject(t *testing.T) {
 	var m = gomocker.NewMocker(t)
 
 	// expect
-	m.Mock(doRequestProcessing).Expects(sut).Returns(dummyResponseObject, dummyResponseError).Once()
+	m.Mock(doRequestProcessing).Expects(sut, dummyContext).Returns(dummyResponseObject, dummyResponseError).Once()
 	m.Mock(getDataTemplate).Expects(dummySession, dummyStatusCode, dummyDataReceivers).Returns(&dummyDataTemplate).Once()
 	m.Mock(parseResponse).Expects(dummySession, dummyBody, gomocker.Anything()).Returns(dummyParseError).SideEffects(
 		gomocker.ParamSideEffect(1, 3, func(value *string) { *value = dummyData })).Once()
 
 	// act
-	var result, header, err = sut.Process()
+	var result, header, err = processWebRequest(
+		sut,
+		dummyContext,
+	)
 
 	// assert
 	assert.Equal(t, dummyData, dummyDataTemplate)
 	assert.Equal(t, dummyStatusCode, result)
 	assert.Equal(t, http.Header(dummyHeader), header)
 	assert.Equal(t, dummyParseError, err)
+	assert.True(t, sut.responseReceived)
+}
+
+func TestProcessAs_Success(t *testing.T) {
+	// arrange
+	type dummyResult struct{ Foo string }
+	type dummyFailure struct{ Bar int }
+	var dummyStatusCode = http.StatusOK + rand.IntN(100)
+	var dummyHeader = http.Header{"foo": {"bar"}}
+	var dummyError = errors.New("some error")
+	var dummyDataReceivers = []dataReceiver{
+		{nil, StatusCodeRange{0, 999}},
+	}
+	var processReceivers []dataReceiver
+
+	// SUT
+	var sut = &webRequest{
+		dataReceivers: dummyDataReceivers,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*webRequest).Process).Expects(sut).Returns(dummyStatusCode, dummyHeader, dummyError).SideEffects(
+		gomocker.ParamSideEffect(1, 1, func(value *webRequest) {
+			processReceivers = value.dataReceivers
+			value.responseReceived = true
+		})).Once()
+
+	// act
+	var result, failure, statusCode, header, err = ProcessAs[dummyResult, dummyFailure](
+		sut,
+	)
+
+	// assert
+	assert.Equal(t, &dummyResult{}, result)
+	assert.Nil(t, failure)
+	assert.Equal(t, dummyStatusCode, statusCode)
+	assert.Equal(t, dummyHeader, header)
+	assert.Equal(t, dummyError, err)
+	assert.Equal(t, 3, len(processReceivers))
+	assert.IsType(t, &dummyFailure{}, processReceivers[1].dataTemplate)
+	assert.Equal(t, StatusCodeRange{0, 999}, processReceivers[1].codeRange)
+	assert.Equal(t, result, processReceivers[2].dataTemplate)
+	assert.Equal(t, StatusCodeRange{http.StatusOK, http.StatusMultipleChoices}, processReceivers[2].codeRange)
+	assert.Equal(t, dummyDataReceivers, sut.dataReceivers)
+}
+
+func TestProcessAs_Failure(t *testing.T) {
+	// arrange
+	type dummyResult struct{ Foo string }
+	type dummyFailure struct{ Bar int }
+	var dummyStatusCode = http.StatusBadRequest + rand.IntN(100)
+	var dummyHeader = http.Header{"foo": {"bar"}}
+	var processReceivers []dataReceiver
+
+	// SUT
+	var sut = &webRequest{}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*webRequest).Process).Expects(sut).Returns(dummyStatusCode, dummyHeader, nil).SideEffects(
+		gomocker.ParamSideEffect(1, 1, func(value *webRequest) {
+			processReceivers = value.dataReceivers
+			value.responseReceived = true
+		})).Once()
+
+	// act
+	var result, failure, statusCode, header, err = ProcessAs[dummyResult, dummyFailure](
+		sut,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, &dummyFailure{}, failure)
+	assert.Equal(t, dummyStatusCode, statusCode)
+	assert.Equal(t, dummyHeader, header)
+	assert.NoError(t, err)
+	assert.Equal(t, failure, processReceivers[0].dataTemplate)
+	assert.Empty(t, sut.dataReceivers)
+}
+
+func TestProcessAs_NoResponse(t *testing.T) {
+	// arrange
+	type dummyResult struct{ Foo string }
+	type dummyFailure struct{ Bar int }
+	var dummyHeader = http.Header{}
+	var dummyError = errors.New("some error")
+
+	// SUT
+	var sut = &webRequest{
+		responseReceived: true,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock((*webRequest).Process).Expects(sut).Returns(http.StatusInternalServerError, dummyHeader, dummyError).SideEffects(
+		gomocker.ParamSideEffect(1, 1, func(value *webRequest) {
+			value.responseReceived = false
+		})).Once()
+
+	// act
+	var result, failure, statusCode, header, err = ProcessAs[dummyResult, dummyFailure](
+		sut,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Nil(t, failure)
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+	assert.Equal(t, dummyHeader, header)
+	assert.Equal(t, dummyError, err)
+	assert.Empty(t, sut.dataReceivers)
+}
+
+type dummyProcessAsWebRequest struct {
+	WebRequest
+	statusCode int
+}
+
+func (webRequest *dummyProcessAsWebRequest) Anticipate(dataTemplate any, statusCodes ...any) WebRequest {
+	return webRequest
+}
+
+func (webRequest *dummyProcessAsWebRequest) Process() (int, http.Header, error) {
+	return webRequest.statusCode, http.Header{}, nil
+}
+
+func TestProcessAs_CustomWebRequest(t *testing.T) {
+	// arrange
+	type dummyResult struct{ Foo string }
+	type dummyFailure struct{ Bar int }
+
+	// SUT
+	var sut = &dummyProcessAsWebRequest{
+		statusCode: http.StatusBadRequest,
+	}
+
+	// act
+	var result, failure, statusCode, header, err = ProcessAs[dummyResult, dummyFailure](
+		sut,
+	)
+
+	// assert
+	assert.Nil(t, result)
+	assert.Equal(t, &dummyFailure{}, failure)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Equal(t, http.Header{}, header)
+	assert.NoError(t, err)
 }
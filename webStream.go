@@ -0,0 +1,148 @@
+package webserver
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// streamCounter wraps a stream and counts the bytes read through it, so that streamed bodies could still be logged by sizes
+type streamCounter struct {
+	reader    io.Reader
+	byteCount atomic.Int64
+}
+
+// Read reads from the underlying stream and accumulates the number of bytes read
+func (counter *streamCounter) Read(data []byte) (int, error) {
+	var count, readError = counter.reader.Read(data)
+	counter.byteCount.Add(int64(count))
+	return count, readError
+}
+
+// streamReceiver is the data template registered by AnticipateStream, handing out the response body as a stream instead of deserializing it
+type streamReceiver struct {
+	handler func(body io.Reader) error
+}
+
+func isStreamResponse(response *http.Response, dataReceivers []dataReceiver) bool {
+	if response == nil {
+		return false
+	}
+	var dataTemplate any
+	for _, dataReceiver := range dataReceivers {
+		if dataReceiver.codeRange.Begin <= response.StatusCode &&
+			dataReceiver.codeRange.End > response.StatusCode {
+			dataTemplate = dataReceiver.dataTemplate
+		}
+	}
+	var _, isStream = dataTemplate.(*streamReceiver)
+	return isStream
+}
+
+func getWebcallRequestBody(webRequest *webRequest) io.Reader {
+	if webRequest.payloadStream != nil {
+		return webRequest.payloadStream
+	}
+	return strings.NewReader(
+		webRequest.payload,
+	)
+}
+
+func logRequestPayload(webRequest *webRequest) {
+	if webRequest.payloadStream != nil {
+		logWebcallRequest(
+			webRequest.session,
+			"Payload",
+			"Stream",
+			"Streaming from %T",
+			webRequest.payloadStream.reader,
+		)
+		return
+	}
+	logWebcallRequest(
+		webRequest.session,
+		"Payload",
+		"Content",
+		"%s",
+		webRequest.payload,
+	)
+}
+
+func logStreamedPayload(webRequest *webRequest) {
+	if webRequest.payloadStream == nil {
+		return
+	}
+	logWebcallRequest(
+		webRequest.session,
+		"Payload",
+		"Streamed",
+		"%d bytes",
+		webRequest.payloadStream.byteCount.Load(),
+	)
+}
+
+func logStreamResponse(session *session, response *http.Response, startTime time.Time) {
+	if response == nil {
+		return
+	}
+	logWebcallResponse(
+		session,
+		"Header",
+		"Content",
+		"%s",
+		marshalIgnoreError(
+			response.Header,
+		),
+	)
+	logWebcallResponse(
+		session,
+		"Body",
+		"Stream",
+		"Content-Length: %d",
+		response.ContentLength,
+	)
+	logWebcallFinish(
+		session,
+		http.StatusText(response.StatusCode),
+		strconv.Itoa(response.StatusCode),
+		"%s",
+		time.Since(startTime),
+	)
+}
+
+func consumeResponseStream(session *session, body io.ReadCloser, receiver *streamReceiver) error {
+	defer body.Close()
+	var counter = &streamCounter{
+		reader: body,
+	}
+	var startTime = getTimeNowUTC()
+	var streamError = receiver.handler(
+		counter,
+	)
+	logWebcallResponse(
+		session,
+		"Body",
+		"Streamed",
+		"%d bytes in %s",
+		counter.byteCount.Load(),
+		time.Since(startTime),
+	)
+	if streamError != nil {
+		logWebcallResponse(
+			session,
+			"Body",
+			"StreamError",
+			"%+v",
+			streamError,
+		)
+		return newAppError(
+			errorCodeGeneralFailure,
+			errorMessageResponseStreamFailed,
+			streamError,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+package webserver
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhongjie-cai/gomocker/v2"
+)
+
+func TestStreamCounterRead(t *testing.T) {
+	// arrange
+	var dummyContent = "some content"
+	var dummyData = make([]byte, 4)
+
+	// SUT
+	var sut = &streamCounter{
+		reader: strings.NewReader(dummyContent),
+	}
+
+	// act
+	var count1, err1 = sut.Read(dummyData)
+	var rest, err2 = io.ReadAll(sut)
+
+	// assert
+	assert.Equal(t, 4, count1)
+	assert.NoError(t, err1)
+	assert.Equal(t, dummyContent[4:], string(rest))
+	assert.NoError(t, err2)
+	assert.Equal(t, int64(len(dummyContent)), sut.byteCount.Load())
+}
+
+func TestIsStreamResponse_NilResponse(t *testing.T) {
+	// arrange
+	var dummyDataReceivers = []dataReceiver{
+		{&streamReceiver{}, StatusCodeRange{0, 999}},
+	}
+
+	// SUT + act
+	var result = isStreamResponse(
+		nil,
+		dummyDataReceivers,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsStreamResponse_NotStream(t *testing.T) {
+	// arrange
+	var dummyStatusCode = rand.IntN(100) + 100
+	var dummyResponse = &http.Response{StatusCode: dummyStatusCode}
+	var dummyDataTemplate string
+	var dummyDataReceivers = []dataReceiver{
+		{&streamReceiver{}, StatusCodeRange{0, 999}},
+		{&dummyDataTemplate, StatusCodeRange{dummyStatusCode, dummyStatusCode + 1}},
+	}
+
+	// SUT + act
+	var result = isStreamResponse(
+		dummyResponse,
+		dummyDataReceivers,
+	)
+
+	// assert
+	assert.False(t, result)
+}
+
+func TestIsStreamResponse_Stream(t *testing.T) {
+	// arrange
+	var dummyStatusCode = rand.IntN(100) + 100
+	var dummyResponse = &http.Response{StatusCode: dummyStatusCode}
+	var dummyDataTemplate string
+	var dummyDataReceivers = []dataReceiver{
+		{&dummyDataTemplate, StatusCodeRange{0, 999}},
+		{&streamReceiver{}, StatusCodeRange{dummyStatusCode, dummyStatusCode + 1}},
+	}
+
+	// SUT + act
+	var result = isStreamResponse(
+		dummyResponse,
+		dummyDataReceivers,
+	)
+
+	// assert
+	assert.True(t, result)
+}
+
+func TestGetWebcallRequestBody_Stream(t *testing.T) {
+	// arrange
+	var dummyStream = &streamCounter{}
+	var dummyWebRequest = &webRequest{
+		payload:       "some payload",
+		payloadStream: dummyStream,
+	}
+
+	// SUT + act
+	var result = getWebcallRequestBody(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyStream, result)
+}
+
+func TestGetWebcallRequestBody_Payload(t *testing.T) {
+	// arrange
+	var dummyPayload = "some payload"
+	var dummyWebRequest = &webRequest{
+		payload: dummyPayload,
+	}
+	var dummyReader = strings.NewReader(dummyPayload)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(strings.NewReader).Expects(dummyPayload).Returns(dummyReader).Once()
+
+	// SUT + act
+	var result = getWebcallRequestBody(
+		dummyWebRequest,
+	)
+
+	// assert
+	assert.Equal(t, dummyReader, result)
+}
+
+func TestLogRequestPayload_Stream(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyReader = &bytes.Buffer{}
+	var dummyWebRequest = &webRequest{
+		session: dummySession,
+		payloadStream: &streamCounter{
+			reader: dummyReader,
+		},
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logWebcallRequest).Expects(dummySession, "Payload", "Stream", "Streaming from %T", dummyReader).Returns().Once()
+
+	// SUT + act
+	logRequestPayload(
+		dummyWebRequest,
+	)
+}
+
+func TestLogRequestPayload_Payload(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyPayload = "some payload"
+	var dummyWebRequest = &webRequest{
+		session: dummySession,
+		payload: dummyPayload,
+	}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logWebcallRequest).Expects(dummySession, "Payload", "Content", "%s", dummyPayload).Returns().Once()
+
+	// SUT + act
+	logRequestPayload(
+		dummyWebRequest,
+	)
+}
+
+func TestLogStreamedPayload_NoStream(t *testing.T) {
+	// arrange
+	var dummyWebRequest = &webRequest{
+		session: &session{id: uuid.New()},
+	}
+
+	// SUT + act
+	logStreamedPayload(
+		dummyWebRequest,
+	)
+}
+
+func TestLogStreamedPayload_Stream(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyByteCount = rand.Int64N(1000)
+	var dummyStream = &streamCounter{}
+	var dummyWebRequest = &webRequest{
+		session:       dummySession,
+		payloadStream: dummyStream,
+	}
+
+	// stub
+	dummyStream.byteCount.Store(dummyByteCount)
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(logWebcallRequest).Expects(dummySession, "Payload", "Streamed", "%d bytes", dummyByteCount).Returns().Once()
+
+	// SUT + act
+	logStreamedPayload(
+		dummyWebRequest,
+	)
+}
+
+func TestLogStreamResponse_NilResponse(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyStartTime = time.Now()
+
+	// SUT + act
+	logStreamResponse(
+		dummySession,
+		nil,
+		dummyStartTime,
+	)
+}
+
+func TestLogStreamResponse_ValidResponse(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyStatus = "some status"
+	var dummyStatusCode = rand.IntN(1000)
+	var dummyContentLength = rand.Int64N(1000)
+	var dummyHeader = http.Header{
+		"foo": []string{"bar"},
+	}
+	var dummyResponse = &http.Response{
+		StatusCode:    dummyStatusCode,
+		Header:        dummyHeader,
+		ContentLength: dummyContentLength,
+	}
+	var dummyStartTime = time.Now()
+	var dummyHeaderContent = "some header content"
+	var dummyTimeSince = time.Duration(rand.IntN(1000))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(marshalIgnoreError).Expects(dummyHeader).Returns(dummyHeaderContent).Once()
+	m.Mock(logWebcallResponse).Expects(dummySession, "Header", "Content", "%s", dummyHeaderContent).Returns().Once()
+	m.Mock(logWebcallResponse).Expects(dummySession, "Body", "Stream", "Content-Length: %d", dummyContentLength).Returns().Once()
+	m.Mock(http.StatusText).Expects(dummyStatusCode).Returns(dummyStatus).Once()
+	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyTimeSince).Once()
+	m.Mock(logWebcallFinish).Expects(dummySession, dummyStatus, strconv.Itoa(dummyStatusCode), "%s", dummyTimeSince).Returns().Once()
+
+	// SUT + act
+	logStreamResponse(
+		dummySession,
+		dummyResponse,
+		dummyStartTime,
+	)
+}
+
+func TestConsumeResponseStream_HandlerError(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyContent = "some content"
+	var dummyBody = io.NopCloser(strings.NewReader(dummyContent))
+	var dummyError = errors.New("some error")
+	var dummyReceiver = &streamReceiver{
+		handler: func(body io.Reader) error {
+			io.ReadAll(body)
+			return dummyError
+		},
+	}
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.IntN(1000))
+	var dummyAppError = &appError{Message: "some error message"}
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyTimeSince).Once()
+	m.Mock(logWebcallResponse).Expects(dummySession, "Body", "Streamed", "%d bytes in %s", int64(len(dummyContent)), dummyTimeSince).Returns().Once()
+	m.Mock(logWebcallResponse).Expects(dummySession, "Body", "StreamError", "%+v", dummyError).Returns().Once()
+	m.Mock(newAppError).Expects(errorCodeGeneralFailure, errorMessageResponseStreamFailed, dummyError).Returns(dummyAppError).Once()
+
+	// SUT + act
+	var err = consumeResponseStream(
+		dummySession,
+		dummyBody,
+		dummyReceiver,
+	)
+
+	// assert
+	assert.Equal(t, dummyAppError, err)
+}
+
+func TestConsumeResponseStream_HappyPath(t *testing.T) {
+	// arrange
+	var dummySession = &session{id: uuid.New()}
+	var dummyContent = "some content"
+	var dummyBody = io.NopCloser(strings.NewReader(dummyContent))
+	var dummyWriter = &bytes.Buffer{}
+	var dummyReceiver = &streamReceiver{
+		handler: func(body io.Reader) error {
+			var _, copyError = io.Copy(dummyWriter, body)
+			return copyError
+		},
+	}
+	var dummyStartTime = time.Now()
+	var dummyTimeSince = time.Duration(rand.IntN(1000))
+
+	// mock
+	var m = gomocker.NewMocker(t)
+
+	// expect
+	m.Mock(getTimeNowUTC).Expects().Returns(dummyStartTime).Once()
+	m.Mock(time.Since).Expects(dummyStartTime).Returns(dummyTimeSince).Once()
+	m.Mock(logWebcallResponse).Expects(dummySession, "Body", "Streamed", "%d bytes in %s", int64(len(dummyContent)), dummyTimeSince).Returns().Once()
+
+	// SUT + act
+	var err = consumeResponseStream(
+		dummySession,
+		dummyBody,
+		dummyReceiver,
+	)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, dummyContent, dummyWriter.String())
+}